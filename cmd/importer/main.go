@@ -0,0 +1,82 @@
+// Command importer upserts products from a supplier CSV with name, price and
+// type columns, the same way as POST /product/import.
+//
+//	go run ./cmd/importer -file prices.csv -report rejected.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/elangreza/lion-superindo/cmd/server/config"
+	postgreRepo "github.com/elangreza/lion-superindo/internal/postgresql"
+	redisRepo "github.com/elangreza/lion-superindo/internal/redis"
+	"github.com/elangreza/lion-superindo/internal/service"
+
+	_ "github.com/lib/pq"
+)
+
+func main() {
+	file := flag.String("file", "", "CSV file to import, - reads from stdin")
+	report := flag.String("report", "", "write the rejected rows as CSV to this file")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	errChecker(err)
+
+	db, err := config.SetupDB(cfg)
+	errChecker(err)
+	defer db.Close()
+
+	redisClient, err := config.SetupCache(cfg)
+	errChecker(err)
+	defer redisClient.Close()
+
+	svc := service.NewProductService(
+		postgreRepo.NewRepo(db),
+		redisRepo.NewRepo(redisClient),
+		service.ProductServiceConfig{StrictProductType: cfg.STRICT_PRODUCT_TYPE},
+	)
+
+	var src io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		errChecker(err)
+		defer f.Close()
+		src = f
+	}
+
+	// batches committed before an interrupt stay imported
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := svc.ImportProducts(ctx, src)
+	errChecker(err)
+
+	slog.Info("import finished", "created", res.Created, "updated", res.Updated, "rejected", len(res.Rejected))
+
+	if *report != "" {
+		f, err := os.Create(*report)
+		errChecker(err)
+		defer f.Close()
+		errChecker(res.WriteReport(f))
+		slog.Info("report written", "file", *report)
+	}
+}
+
+func errChecker(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/joho/godotenv"
 
 	kenv "github.com/knadh/koanf/providers/env"
@@ -17,6 +19,12 @@ type Config struct {
 	POSTGRES_SSL      string `koanf:"POSTGRES_SSL"`
 	REDIS_HOSTNAME    string `koanf:"REDIS_HOSTNAME"`
 	REDIS_PORT        string `koanf:"REDIS_PORT"`
+	// STRICT_PRODUCT_TYPE rejects unknown product types instead of creating them
+	STRICT_PRODUCT_TYPE bool `koanf:"STRICT_PRODUCT_TYPE"`
+	// PRICE_SCHEDULER_INTERVAL is how often due scheduled prices are applied, 1m by default
+	PRICE_SCHEDULER_INTERVAL time.Duration `koanf:"PRICE_SCHEDULER_INTERVAL"`
+	// RESERVATION_SWEEPER_INTERVAL is how often expired reservations are released, 1m by default
+	RESERVATION_SWEEPER_INTERVAL time.Duration `koanf:"RESERVATION_SWEEPER_INTERVAL"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -34,5 +42,13 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if config.PRICE_SCHEDULER_INTERVAL <= 0 {
+		config.PRICE_SCHEDULER_INTERVAL = time.Minute
+	}
+
+	if config.RESERVATION_SWEEPER_INTERVAL <= 0 {
+		config.RESERVATION_SWEEPER_INTERVAL = time.Minute
+	}
+
 	return &config, nil
 }
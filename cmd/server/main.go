@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/elangreza/lion-superindo/cmd/server/config"
+	"github.com/elangreza/lion-superindo/internal/worker"
 
 	_ "github.com/elangreza/lion-superindo/docs"
 	_ "github.com/lib/pq"
@@ -44,29 +45,63 @@ func main() {
 
 	go func() {
 		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			log.Fatalf("HTTP server ListenAndServe: %w", err)
+			log.Fatalf("HTTP server ListenAndServe: %v", err)
 		}
 	}()
 
 	slog.Info("server started", "port", cfg.HTTP_PORT)
 
+	priceScheduler := worker.New("price scheduler", cfg.PRICE_SCHEDULER_INTERVAL, func(ctx context.Context) error {
+		applied, err := deps.ProductService.ApplyScheduledPrices(ctx)
+		if applied > 0 {
+			slog.Info("price scheduler", "applied", applied)
+		}
+		return err
+	})
+	priceScheduler.Start(context.Background())
+
+	reservationSweeper := worker.New("reservation sweeper", cfg.RESERVATION_SWEEPER_INTERVAL, func(ctx context.Context) error {
+		expired, err := deps.ReservationService.ExpireReservations(ctx)
+		if expired > 0 {
+			slog.Info("reservation sweeper", "expired", expired)
+		}
+		return err
+	})
+	reservationSweeper.Start(context.Background())
+
+	// requests and worker runs in progress still use postgres and redis, so
+	// they are stopped before the connections are closed
 	<-gracefulShutdown(context.Background(), 5*time.Second,
-		operation{
-			name: "server",
-			shutdownFunc: func(ctx context.Context) error {
-				return srv.Shutdown(ctx)
-			}},
-		operation{
-			name: "postgres",
-			shutdownFunc: func(ctx context.Context) error {
-				return deps.DB.Close()
-			}},
-		operation{
-			name: "redis",
-			shutdownFunc: func(ctx context.Context) error {
-				deps.RedisClient.Close()
-				return nil
-			}},
+		[]operation{
+			{
+				name: "server",
+				shutdownFunc: func(ctx context.Context) error {
+					return srv.Shutdown(ctx)
+				}},
+			{
+				name: "price scheduler",
+				shutdownFunc: func(ctx context.Context) error {
+					return priceScheduler.Shutdown(ctx)
+				}},
+			{
+				name: "reservation sweeper",
+				shutdownFunc: func(ctx context.Context) error {
+					return reservationSweeper.Shutdown(ctx)
+				}},
+		},
+		[]operation{
+			{
+				name: "postgres",
+				shutdownFunc: func(ctx context.Context) error {
+					return deps.DB.Close()
+				}},
+			{
+				name: "redis",
+				shutdownFunc: func(ctx context.Context) error {
+					deps.RedisClient.Close()
+					return nil
+				}},
+		},
 	)
 }
 
@@ -81,7 +116,9 @@ type operation struct {
 	shutdownFunc func(ctx context.Context) error
 }
 
-func gracefulShutdown(ctx context.Context, timeout time.Duration, ops ...operation) <-chan struct{} {
+// gracefulShutdown runs the phases one after another, the operations of a
+// phase are shut down concurrently.
+func gracefulShutdown(ctx context.Context, timeout time.Duration, phases ...[]operation) <-chan struct{} {
 	wait := make(chan struct{})
 	go func() {
 		s := make(chan os.Signal, 1)
@@ -100,25 +137,27 @@ func gracefulShutdown(ctx context.Context, timeout time.Duration, ops ...operati
 			wait <- struct{}{}
 		}()
 
-		var wg sync.WaitGroup
+		for _, ops := range phases {
+			var wg sync.WaitGroup
 
-		for key, op := range ops {
-			wg.Add(1)
-			go func(key int, op operation) {
-				defer wg.Done()
+			for key, op := range ops {
+				wg.Add(1)
+				go func(key int, op operation) {
+					defer wg.Done()
 
-				slog.Info(op.name, "shutdown", "started")
+					slog.Info(op.name, "shutdown", "started")
 
-				if err := op.shutdownFunc(ctx); err != nil {
-					slog.Error(op.name, "err", err.Error())
-					return
-				}
+					if err := op.shutdownFunc(ctx); err != nil {
+						slog.Error(op.name, "err", err.Error())
+						return
+					}
 
-				slog.Info(op.name, "shutdown", "finished")
-			}(key, op)
-		}
+					slog.Info(op.name, "shutdown", "finished")
+				}(key, op)
+			}
 
-		wg.Wait()
+			wg.Wait()
+		}
 	}()
 
 	return wait
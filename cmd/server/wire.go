@@ -17,9 +17,12 @@ import (
 )
 
 type ProductHandlerDeps struct {
-	Mux         *http.ServeMux
-	DB          *sql.DB
-	RedisClient *redis.Client
+	Mux            *http.ServeMux
+	DB             *sql.DB
+	RedisClient    *redis.Client
+	ProductService *service.ProductService
+	// ReservationService releases the expired reservations in the background
+	ReservationService *service.ReservationService
 }
 
 var productSet = wire.NewSet(
@@ -29,16 +32,39 @@ var productSet = wire.NewSet(
 	wire.Bind(new(service.DbRepo), new(*postgreRepo.PostgresRepo)), // <-- Bind DbRepo interface
 	redisRepo.NewRepo,
 	wire.Bind(new(service.CacheRepo), new(*redisRepo.RedisRepo)), // <-- Bind CacheRepo interface
+	newProductServiceConfig,
 	service.NewProductService,
 	wire.Bind(new(handler.ProductService), new(*service.ProductService)), // <-- This line binds interface to implementation
 	handler.NewProductHandler,
+	wire.Bind(new(service.ProductTypeRepo), new(*postgreRepo.PostgresRepo)),
+	service.NewProductTypeService,
+	wire.Bind(new(handler.ProductTypeService), new(*service.ProductTypeService)),
+	handler.NewProductTypeHandler,
+	wire.Bind(new(service.PromotionRepo), new(*postgreRepo.PostgresRepo)),
+	service.NewPromotionService,
+	wire.Bind(new(handler.PromotionService), new(*service.PromotionService)),
+	handler.NewPromotionHandler,
+	wire.Bind(new(service.StoreRepo), new(*postgreRepo.PostgresRepo)),
+	service.NewStoreService,
+	wire.Bind(new(handler.StoreService), new(*service.StoreService)),
+	handler.NewStoreHandler,
+	wire.Bind(new(service.ReservationRepo), new(*postgreRepo.PostgresRepo)),
+	service.NewReservationService,
+	wire.Bind(new(handler.ReservationService), new(*service.ReservationService)),
+	handler.NewReservationHandler,
 	handler.NewRoutes,
 )
 
+func newProductServiceConfig(cfg *config.Config) service.ProductServiceConfig {
+	return service.ProductServiceConfig{
+		StrictProductType: cfg.STRICT_PRODUCT_TYPE,
+	}
+}
+
 func InitializeProductHandler(cfg *config.Config) (*ProductHandlerDeps, error) {
 	wire.Build(
 		productSet,
-		wire.Struct(new(ProductHandlerDeps), "Mux", "DB", "RedisClient"),
+		wire.Struct(new(ProductHandlerDeps), "Mux", "DB", "RedisClient", "ProductService", "ReservationService"),
 	)
 	return nil, nil
 }
@@ -36,13 +36,24 @@ func InitializeProductHandler(cfg *config.Config) (*ProductHandlerDeps, error) {
 		return nil, err
 	}
 	redisRepo := redis.NewRepo(client)
-	productService := service.NewProductService(postgresRepo, redisRepo)
+	productServiceConfig := newProductServiceConfig(cfg)
+	productService := service.NewProductService(postgresRepo, redisRepo, productServiceConfig)
 	productHandler := handler.NewProductHandler(productService)
-	serveMux := handler.NewRoutes(productHandler)
+	productTypeService := service.NewProductTypeService(postgresRepo, redisRepo)
+	productTypeHandler := handler.NewProductTypeHandler(productTypeService)
+	promotionService := service.NewPromotionService(postgresRepo, redisRepo)
+	promotionHandler := handler.NewPromotionHandler(promotionService)
+	storeService := service.NewStoreService(postgresRepo, redisRepo)
+	storeHandler := handler.NewStoreHandler(storeService)
+	reservationService := service.NewReservationService(postgresRepo, redisRepo)
+	reservationHandler := handler.NewReservationHandler(reservationService)
+	serveMux := handler.NewRoutes(productHandler, productTypeHandler, promotionHandler, storeHandler, reservationHandler)
 	productHandlerDeps := &ProductHandlerDeps{
-		Mux:         serveMux,
-		DB:          db,
-		RedisClient: client,
+		Mux:                serveMux,
+		DB:                 db,
+		RedisClient:        client,
+		ProductService:     productService,
+		ReservationService: reservationService,
 	}
 	return productHandlerDeps, nil
 }
@@ -50,9 +61,18 @@ func InitializeProductHandler(cfg *config.Config) (*ProductHandlerDeps, error) {
 // wire.go:
 
 type ProductHandlerDeps struct {
-	Mux         *http.ServeMux
-	DB          *sql.DB
-	RedisClient *redis2.Client
+	Mux            *http.ServeMux
+	DB             *sql.DB
+	RedisClient    *redis2.Client
+	ProductService *service.ProductService
+	// ReservationService releases the expired reservations in the background
+	ReservationService *service.ReservationService
 }
 
-var productSet = wire.NewSet(config.SetupDB, config.SetupCache, postgresql.NewRepo, wire.Bind(new(service.DbRepo), new(*postgresql.PostgresRepo)), redis.NewRepo, wire.Bind(new(service.CacheRepo), new(*redis.RedisRepo)), service.NewProductService, wire.Bind(new(handler.ProductService), new(*service.ProductService)), handler.NewProductHandler, handler.NewRoutes)
+var productSet = wire.NewSet(config.SetupDB, config.SetupCache, postgresql.NewRepo, wire.Bind(new(service.DbRepo), new(*postgresql.PostgresRepo)), redis.NewRepo, wire.Bind(new(service.CacheRepo), new(*redis.RedisRepo)), newProductServiceConfig, service.NewProductService, wire.Bind(new(handler.ProductService), new(*service.ProductService)), handler.NewProductHandler, wire.Bind(new(service.ProductTypeRepo), new(*postgresql.PostgresRepo)), service.NewProductTypeService, wire.Bind(new(handler.ProductTypeService), new(*service.ProductTypeService)), handler.NewProductTypeHandler, wire.Bind(new(service.PromotionRepo), new(*postgresql.PostgresRepo)), service.NewPromotionService, wire.Bind(new(handler.PromotionService), new(*service.PromotionService)), handler.NewPromotionHandler, wire.Bind(new(service.StoreRepo), new(*postgresql.PostgresRepo)), service.NewStoreService, wire.Bind(new(handler.StoreService), new(*service.StoreService)), handler.NewStoreHandler, wire.Bind(new(service.ReservationRepo), new(*postgresql.PostgresRepo)), service.NewReservationService, wire.Bind(new(handler.ReservationService), new(*service.ReservationService)), handler.NewReservationHandler, handler.NewRoutes)
+
+func newProductServiceConfig(cfg *config.Config) service.ProductServiceConfig {
+	return service.ProductServiceConfig{
+		StrictProductType: cfg.STRICT_PRODUCT_TYPE,
+	}
+}
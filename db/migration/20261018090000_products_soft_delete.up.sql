@@ -0,0 +1,8 @@
+BEGIN
+;
+
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "deleted_at" TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS "products_deleted_at_idx" ON "products" ("deleted_at");
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "products_search_vector_idx";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN
+;
+
+ALTER TABLE "products"
+ADD COLUMN IF NOT EXISTS "search_vector" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', "name")) STORED;
+
+CREATE INDEX IF NOT EXISTS "products_search_vector_idx" ON "products" USING GIN ("search_vector");
+
+COMMIT;
\ No newline at end of file
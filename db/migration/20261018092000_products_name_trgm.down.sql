@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "products_name_trgm_idx";
+
+DROP EXTENSION IF EXISTS pg_trgm;
+
+COMMIT;
\ No newline at end of file
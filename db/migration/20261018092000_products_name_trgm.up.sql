@@ -0,0 +1,8 @@
+BEGIN
+;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS "products_name_trgm_idx" ON "products" USING GIN (LOWER("name") gin_trgm_ops);
+
+COMMIT;
\ No newline at end of file
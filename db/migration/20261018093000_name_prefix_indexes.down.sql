@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "product_types_name_prefix_idx";
+
+DROP INDEX IF EXISTS "products_name_prefix_idx";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+CREATE INDEX IF NOT EXISTS "products_name_prefix_idx" ON "products" (LOWER("name") text_pattern_ops);
+
+CREATE INDEX IF NOT EXISTS "product_types_name_prefix_idx" ON "product_types" (LOWER("name") text_pattern_ops);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,9 @@
+BEGIN
+;
+
+ALTER TABLE "products" DROP CONSTRAINT IF EXISTS "products_product_type_name_fkey";
+
+ALTER TABLE "products"
+ADD CONSTRAINT "products_product_type_name_fkey" FOREIGN KEY ("product_type_name") REFERENCES product_types("name") ON UPDATE CASCADE;
+
+COMMIT;
\ No newline at end of file
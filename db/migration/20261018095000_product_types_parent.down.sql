@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "product_types_parent_name_idx";
+
+ALTER TABLE "product_types" DROP COLUMN IF EXISTS "parent_name";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,12 @@
+BEGIN
+;
+
+ALTER TABLE "product_types"
+ADD COLUMN IF NOT EXISTS "parent_name" VARCHAR REFERENCES product_types("name") ON UPDATE CASCADE;
+
+ALTER TABLE "product_types"
+ADD CONSTRAINT "product_types_parent_name_check" CHECK ("parent_name" <> "name");
+
+CREATE INDEX IF NOT EXISTS "product_types_parent_name_idx" ON "product_types" ("parent_name");
+
+COMMIT;
\ No newline at end of file
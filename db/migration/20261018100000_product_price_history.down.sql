@@ -0,0 +1,10 @@
+BEGIN
+;
+
+DROP TRIGGER IF EXISTS "products_price_history" ON "products";
+
+DROP FUNCTION IF EXISTS record_product_price();
+
+DROP TABLE IF EXISTS "product_price_history";
+
+COMMIT;
\ No newline at end of file
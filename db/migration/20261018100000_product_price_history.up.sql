@@ -0,0 +1,33 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "product_price_history" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "product_id" BIGINT NOT NULL REFERENCES products("id") ON DELETE CASCADE,
+    "price" BIGINT CHECK(price >= 0),
+    "effective_from" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "product_price_history_product_id_effective_from_idx" ON "product_price_history" ("product_id", "effective_from" DESC);
+
+-- a new product starts its history at creation, an update only adds a row when the price really changes
+CREATE OR REPLACE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from") VALUES (NEW."id", NEW."price", NEW."created_at");
+    ELSIF NEW."price" IS DISTINCT FROM OLD."price" THEN
+        INSERT INTO product_price_history ("product_id", "price") VALUES (NEW."id", NEW."price");
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "products_price_history"
+AFTER INSERT OR UPDATE OF "price" ON "products"
+FOR EACH ROW EXECUTE FUNCTION record_product_price();
+
+-- the current prices of the existing products are their first known prices
+INSERT INTO product_price_history ("product_id", "price", "effective_from")
+SELECT "id", "price", "created_at" FROM products;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,17 @@
+BEGIN
+;
+
+CREATE OR REPLACE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from") VALUES (NEW."id", NEW."price", NEW."created_at");
+    ELSIF NEW."price" IS DISTINCT FROM OLD."price" THEN
+        INSERT INTO product_price_history ("product_id", "price") VALUES (NEW."id", NEW."price");
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS "scheduled_prices";
+
+COMMIT;
\ No newline at end of file
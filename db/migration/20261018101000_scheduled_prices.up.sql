@@ -0,0 +1,30 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "scheduled_prices" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "product_id" BIGINT NOT NULL REFERENCES products("id") ON DELETE CASCADE,
+    "price" BIGINT NOT NULL CHECK(price >= 0),
+    "effective_at" TIMESTAMPTZ NOT NULL,
+    "applied_at" TIMESTAMPTZ,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS "scheduled_prices_pending_idx" ON "scheduled_prices" ("effective_at") WHERE "applied_at" IS NULL;
+
+CREATE INDEX IF NOT EXISTS "scheduled_prices_product_id_idx" ON "scheduled_prices" ("product_id");
+
+-- a scheduled price is recorded from its effective time instead of the time the scheduler applied it
+CREATE OR REPLACE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from") VALUES (NEW."id", NEW."price", NEW."created_at");
+    ELSIF NEW."price" IS DISTINCT FROM OLD."price" THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from")
+        VALUES (NEW."id", NEW."price", COALESCE(NULLIF(current_setting('app.price_effective_from', TRUE), '')::TIMESTAMPTZ, NOW()));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "promotions";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,21 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "promotions" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "discount_type" VARCHAR NOT NULL CHECK("discount_type" IN ('percentage', 'fixed')),
+    "discount_value" BIGINT NOT NULL CHECK("discount_value" > 0),
+    "product_id" BIGINT REFERENCES products("id") ON DELETE CASCADE,
+    "product_type_name" VARCHAR REFERENCES product_types("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "starts_at" TIMESTAMPTZ NOT NULL,
+    "ends_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT "promotions_percentage_check" CHECK("discount_type" <> 'percentage' OR "discount_value" <= 100),
+    CONSTRAINT "promotions_scope_check" CHECK("product_id" IS NULL OR "product_type_name" IS NULL),
+    CONSTRAINT "promotions_period_check" CHECK("ends_at" > "starts_at")
+);
+
+CREATE INDEX IF NOT EXISTS "promotions_ends_at_idx" ON "promotions" ("ends_at");
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,39 @@
+BEGIN
+;
+
+-- prices of other currencies cannot be converted back to rupiah
+DELETE FROM "products" WHERE "currency" <> 'IDR';
+
+DELETE FROM "promotions" WHERE "currency" <> 'IDR';
+
+DROP TRIGGER IF EXISTS "products_price_history" ON "products";
+
+CREATE OR REPLACE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from") VALUES (NEW."id", NEW."price", NEW."created_at");
+    ELSIF NEW."price" IS DISTINCT FROM OLD."price" THEN
+        INSERT INTO product_price_history ("product_id", "price", "effective_from")
+        VALUES (NEW."id", NEW."price", COALESCE(NULLIF(current_setting('app.price_effective_from', TRUE), '')::TIMESTAMPTZ, NOW()));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "products_price_history"
+AFTER INSERT OR UPDATE OF "price" ON "products"
+FOR EACH ROW EXECUTE FUNCTION record_product_price();
+
+DROP INDEX IF EXISTS "products_currency_price_idx";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "product_price_history" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "scheduled_prices" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "promotions" DROP CONSTRAINT IF EXISTS "promotions_currency_check";
+
+ALTER TABLE "promotions" DROP COLUMN IF EXISTS "currency";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,45 @@
+BEGIN
+;
+
+-- prices were whole rupiah, they are now minor units of their currency and IDR has none
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "currency" CHAR(3) NOT NULL DEFAULT 'IDR' CHECK("currency" ~ '^[A-Z]{3}$');
+
+ALTER TABLE "products" ALTER COLUMN "currency" DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS "products_currency_price_idx" ON "products" ("currency", "price");
+
+ALTER TABLE "product_price_history" ADD COLUMN IF NOT EXISTS "currency" CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE "product_price_history" ALTER COLUMN "currency" DROP DEFAULT;
+
+ALTER TABLE "scheduled_prices" ADD COLUMN IF NOT EXISTS "currency" CHAR(3) NOT NULL DEFAULT 'IDR';
+
+ALTER TABLE "scheduled_prices" ALTER COLUMN "currency" DROP DEFAULT;
+
+-- a fixed discount is an amount of a currency, it only applies to the prices of that currency
+ALTER TABLE "promotions" ADD COLUMN IF NOT EXISTS "currency" CHAR(3);
+
+UPDATE "promotions" SET "currency" = 'IDR' WHERE "discount_type" = 'fixed';
+
+ALTER TABLE "promotions" ADD CONSTRAINT "promotions_currency_check" CHECK(("discount_type" = 'fixed') = ("currency" IS NOT NULL));
+
+-- changing the currency is a price change as well
+CREATE OR REPLACE FUNCTION record_product_price() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO product_price_history ("product_id", "price", "currency", "effective_from") VALUES (NEW."id", NEW."price", NEW."currency", NEW."created_at");
+    ELSIF NEW."price" IS DISTINCT FROM OLD."price" OR NEW."currency" IS DISTINCT FROM OLD."currency" THEN
+        INSERT INTO product_price_history ("product_id", "price", "currency", "effective_from")
+        VALUES (NEW."id", NEW."price", NEW."currency", COALESCE(NULLIF(current_setting('app.price_effective_from', TRUE), '')::TIMESTAMPTZ, NOW()));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "products_price_history" ON "products";
+
+CREATE TRIGGER "products_price_history"
+AFTER INSERT OR UPDATE OF "price", "currency" ON "products"
+FOR EACH ROW EXECUTE FUNCTION record_product_price();
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,8 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "store_inventory";
+
+DROP TABLE IF EXISTS "stores";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,23 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "stores" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" VARCHAR NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "stores_name_lower_idx" ON "stores" (LOWER("name"));
+
+CREATE TABLE IF NOT EXISTS "store_inventory" (
+    "store_id" BIGINT NOT NULL REFERENCES stores("id") ON DELETE CASCADE,
+    "product_id" BIGINT NOT NULL REFERENCES products("id") ON DELETE CASCADE,
+    "quantity" BIGINT NOT NULL DEFAULT 0 CHECK("quantity" >= 0),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY ("store_id", "product_id")
+);
+
+-- serves the in_stock_at filter, which only looks for available items
+CREATE INDEX IF NOT EXISTS "store_inventory_in_stock_idx" ON "store_inventory" ("store_id", "product_id") WHERE "quantity" > 0;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "stock_reservations";
+
+COMMIT;
\ No newline at end of file
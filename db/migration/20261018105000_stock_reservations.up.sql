@@ -0,0 +1,20 @@
+BEGIN
+;
+
+-- the quantity of a pending or confirmed reservation is taken out of the store inventory,
+-- cancelling or expiring a pending one puts it back
+CREATE TABLE IF NOT EXISTS "stock_reservations" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "store_id" BIGINT NOT NULL,
+    "product_id" BIGINT NOT NULL,
+    "quantity" BIGINT NOT NULL CHECK("quantity" > 0),
+    "status" VARCHAR NOT NULL DEFAULT 'pending' CHECK("status" IN ('pending', 'confirmed', 'cancelled', 'expired')),
+    "expires_at" TIMESTAMPTZ NOT NULL,
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY ("store_id", "product_id") REFERENCES store_inventory("store_id", "product_id") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "stock_reservations_pending_idx" ON "stock_reservations" ("expires_at") WHERE "status" = 'pending';
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,12 @@
+BEGIN
+;
+
+DROP INDEX IF EXISTS "products_barcode_idx";
+
+DROP INDEX IF EXISTS "products_sku_idx";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "barcode";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "sku";
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,13 @@
+BEGIN
+;
+
+-- both codes are optional, NULLs do not clash in the unique indexes
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "sku" VARCHAR(64);
+
+ALTER TABLE "products" ADD COLUMN IF NOT EXISTS "barcode" CHAR(13) CHECK ("barcode" ~ '^[0-9]{13}$');
+
+CREATE UNIQUE INDEX IF NOT EXISTS "products_sku_idx" ON "products" ("sku");
+
+CREATE UNIQUE INDEX IF NOT EXISTS "products_barcode_idx" ON "products" ("barcode");
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN
+;
+
+DROP TABLE IF EXISTS "product_variants";
+
+COMMIT;
\ No newline at end of file
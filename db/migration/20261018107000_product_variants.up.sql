@@ -0,0 +1,23 @@
+BEGIN
+;
+
+CREATE TABLE IF NOT EXISTS "product_variants" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "product_id" BIGINT NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+    -- shares the sku namespace of products, checked by the service
+    "sku" VARCHAR(64) UNIQUE,
+    -- option name to value, e.g. {"flavor": "chocolate", "pack": "6 pcs"}
+    "attributes" JSONB NOT NULL CHECK (jsonb_typeof("attributes") = 'object'),
+    "price" BIGINT NOT NULL CHECK ("price" >= 0),
+    "currency" CHAR(3) NOT NULL CHECK ("currency" ~ '^[A-Z]{3}$'),
+    "created_at" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    "updated_at" TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- a product cannot have two variants with the same options
+CREATE UNIQUE INDEX IF NOT EXISTS "product_variants_product_id_attributes_idx" ON "product_variants" ("product_id", "attributes");
+
+-- serves the attributes @> filter of the product list
+CREATE INDEX IF NOT EXISTS "product_variants_attributes_idx" ON "product_variants" USING GIN ("attributes" jsonb_path_ops);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,40 @@
+package domain
+
+// DefaultCurrency is the currency of the catalog before prices had a currency.
+const DefaultCurrency = "IDR"
+
+// Money is an exact amount in the minor units of an ISO 4217 currency,
+// e.g. {Amount: 1050, Currency: "USD"} is 10.50 US dollars.
+type Money struct {
+	Amount   int
+	Currency string
+}
+
+// currencyMinorUnits are the supported ISO 4217 currencies with the number of
+// digits of their minor unit. IDR amounts are whole rupiah, as the catalog
+// prices always were.
+var currencyMinorUnits = map[string]int{
+	"AUD": 2,
+	"BND": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 0,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"TWD": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// IsCurrency reports whether code is a supported ISO 4217 currency code.
+func IsCurrency(code string) bool {
+	_, ok := currencyMinorUnits[code]
+	return ok
+}
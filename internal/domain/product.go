@@ -3,9 +3,44 @@ package domain
 import "time"
 
 type Product struct {
-	ID          int
-	Name        string
-	Price       int
+	ID   int
+	Name string
+	// SKU and Barcode are empty when the product has none
+	SKU         string
+	Barcode     string
+	Price       Money
 	ProductType ProductType
 	CreatedAt   time.Time
+	DeletedAt   *time.Time
+	// Relevance is the search rank, only set when searching in a ranked search mode
+	Relevance float64
+	Variants  []Variant
+}
+
+// Variant is a purchasable option of a product, such as a flavor or a pack
+// size, with its own price.
+type Variant struct {
+	ID        int
+	ProductID int
+	// SKU is empty when the variant has none
+	SKU string
+	// Attributes maps the option names to their values, e.g. flavor to chocolate
+	Attributes map[string]string
+	Price      Money
+	CreatedAt  time.Time
+}
+
+// ProductPrice is a price of a product, valid from EffectiveFrom until the next change.
+type ProductPrice struct {
+	Price         Money
+	EffectiveFrom time.Time
+}
+
+// ScheduledPrice is a price change applied to a product once EffectiveAt is reached.
+type ScheduledPrice struct {
+	ID          int
+	ProductID   int
+	Price       Money
+	EffectiveAt time.Time
+	CreatedAt   time.Time
 }
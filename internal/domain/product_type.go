@@ -1,8 +1,27 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProductTypeExists is returned by the repository when a product type name
+	// is taken by a concurrent write that passed the same uniqueness check.
+	ErrProductTypeExists = errors.New("product type already exist")
+	// ErrParentNotFound is returned by the repository when a product type is
+	// moved under a parent that does not exist.
+	ErrParentNotFound = errors.New("parent product type not found")
+	// ErrProductTypeCycle is returned by the repository when a product type is
+	// moved under itself or one of its descendants.
+	ErrProductTypeCycle = errors.New("product type cycle")
+)
 
 type ProductType struct {
-	Name      string
-	CreatedAt time.Time
+	Name string
+	// ParentName is the parent category, empty for a root category
+	ParentName string
+	CreatedAt  time.Time
+	// TotalProducts counts the products of this type, only set when reading product types
+	TotalProducts int
 }
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type Promotion struct {
+	ID            int
+	Name          string
+	DiscountType  string
+	DiscountValue int
+	// Currency of a fixed DiscountValue, it only applies to prices of this currency
+	Currency string
+	// scope of the promotion, a zero ProductID and an empty ProductTypeName
+	// cover the whole catalog
+	ProductID       int
+	ProductTypeName string
+	// ProductTypes is ProductTypeName with all of its descendants, only set
+	// when the promotions are loaded for pricing
+	ProductTypes []string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	CreatedAt    time.Time
+}
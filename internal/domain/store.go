@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+type Store struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+}
+
+// StockLevel is the quantity of a product available at a store.
+type StockLevel struct {
+	StoreID     int
+	ProductID   int
+	ProductName string
+	Quantity    int
+	UpdatedAt   time.Time
+}
+
+// Reservation holds Quantity of a product at a store while a customer pays.
+type Reservation struct {
+	ID        int
+	StoreID   int
+	ProductID int
+	Quantity  int
+	Status    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	MediaTypeJSON    = "application/json"
+	MediaTypeCSV     = "text/csv"
+	MediaTypeMsgpack = "application/msgpack"
+)
+
+type (
+	// Encoder writes a success response in one media type.
+	Encoder interface {
+		// CanEncode reports whether res can be written in this media type.
+		CanEncode(res any) bool
+		Encode(w io.Writer, res any) error
+	}
+
+	// CSVMarshaler is implemented by the responses that can be sent as CSV.
+	// The first record is the header.
+	CSVMarshaler interface {
+		MarshalCSV() [][]string
+	}
+
+	registeredEncoder struct {
+		mediaType string
+		encoder   Encoder
+	}
+
+	acceptRange struct {
+		mediaType string
+		quality   float64
+	}
+)
+
+// encoders are tried in the order they are registered, the first one is used
+// when the request has no Accept header.
+var encoders []registeredEncoder
+
+func init() {
+	RegisterEncoder(MediaTypeJSON, jsonEncoder{})
+	RegisterEncoder(MediaTypeCSV, csvEncoder{})
+	RegisterEncoder(MediaTypeMsgpack, msgpackEncoder{})
+}
+
+// RegisterEncoder makes the media type available to the Accept header,
+// an encoder registered again for the same media type replaces the old one.
+func RegisterEncoder(mediaType string, encoder Encoder) {
+	for i := range encoders {
+		if encoders[i].mediaType == mediaType {
+			encoders[i].encoder = encoder
+			return
+		}
+	}
+
+	encoders = append(encoders, registeredEncoder{mediaType: mediaType, encoder: encoder})
+}
+
+// anyResponse are the media types able to write every response, CSV only
+// writes products.
+var anyResponse = []string{MediaTypeJSON, MediaTypeMsgpack}
+
+// negotiate picks the encoder for res from the Accept header, in the client
+// preference order. ok is false when none of the accepted media types can
+// write res.
+func negotiate(accept string, res any) (enc registeredEncoder, ok bool) {
+	for _, accepted := range parseAccept(accept) {
+		for _, enc := range encoders {
+			if mediaTypeMatch(accepted.mediaType, enc.mediaType) && enc.encoder.CanEncode(res) {
+				return enc, true
+			}
+		}
+	}
+
+	return registeredEncoder{}, false
+}
+
+// notAcceptable lists the media types able to write res.
+func notAcceptable(accept string, res any) errs.NotAcceptableError {
+	supported := make([]string, 0, len(encoders))
+	for _, enc := range encoders {
+		if enc.encoder.CanEncode(res) {
+			supported = append(supported, enc.mediaType)
+		}
+	}
+
+	return errs.NotAcceptableError{Accept: accept, Supported: supported}
+}
+
+// acceptable rejects the request with 406 when its Accept header matches none
+// of mediaTypes, or none of the registered media types when mediaTypes is
+// empty, so nothing is processed for a response that cannot be sent. Routes
+// not writing products pass anyResponse.
+func acceptable(next http.HandlerFunc, mediaTypes ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := accepts(r, mediaTypes); err != nil {
+			Error(w, http.StatusNotAcceptable, err)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// accepts returns a NotAcceptableError when the Accept header of r matches
+// none of mediaTypes, see acceptable.
+func accepts(r *http.Request, mediaTypes []string) error {
+	supported := make([]string, 0, len(encoders))
+	for _, enc := range encoders {
+		if len(mediaTypes) == 0 || slices.Contains(mediaTypes, enc.mediaType) {
+			supported = append(supported, enc.mediaType)
+		}
+	}
+
+	accept := r.Header.Get("Accept")
+	for _, accepted := range parseAccept(accept) {
+		for _, mediaType := range supported {
+			if mediaTypeMatch(accepted.mediaType, mediaType) {
+				return nil
+			}
+		}
+	}
+
+	return errs.NotAcceptableError{Accept: accept, Supported: supported}
+}
+
+// parseAccept returns the accepted media types, most preferred first. Ranges
+// with q=0 are left out and an empty header accepts anything.
+func parseAccept(accept string) []acceptRange {
+	if strings.TrimSpace(accept) == "" {
+		return []acceptRange{{mediaType: "*/*", quality: 1}}
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, mediaParams, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := mediaParams["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if quality > 0 {
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+		}
+	}
+
+	// on the same quality the more specific range wins, the order of the
+	// header is kept otherwise
+	slices.SortStableFunc(ranges, func(a, b acceptRange) int {
+		if a.quality != b.quality {
+			if a.quality > b.quality {
+				return -1
+			}
+			return 1
+		}
+		return strings.Count(a.mediaType, "*") - strings.Count(b.mediaType, "*")
+	})
+
+	return ranges
+}
+
+func mediaTypeMatch(accepted, mediaType string) bool {
+	if accepted == "*/*" || accepted == mediaType {
+		return true
+	}
+
+	group, _, _ := strings.Cut(mediaType, "/")
+	return accepted == group+"/*"
+}
+
+type jsonEncoder struct{}
+
+func (jsonEncoder) CanEncode(res any) bool {
+	return true
+}
+
+func (jsonEncoder) Encode(w io.Writer, res any) error {
+	return json.NewEncoder(w).Encode(map[string]any{"data": res})
+}
+
+type msgpackEncoder struct{}
+
+func (msgpackEncoder) CanEncode(res any) bool {
+	return true
+}
+
+func (msgpackEncoder) Encode(w io.Writer, res any) error {
+	encoder := msgpack.NewEncoder(w)
+	// same field names as the JSON responses
+	encoder.SetCustomStructTag("json")
+	return encoder.Encode(map[string]any{"data": res})
+}
+
+// csvEncoder only writes the responses implementing CSVMarshaler.
+type csvEncoder struct{}
+
+func (csvEncoder) CanEncode(res any) bool {
+	_, ok := res.(CSVMarshaler)
+	return ok
+}
+
+func (csvEncoder) Encode(w io.Writer, res any) error {
+	return csv.NewWriter(w).WriteAll(res.(CSVMarshaler).MarshalCSV())
+}
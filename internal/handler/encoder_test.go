@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseAccept(t *testing.T) {
+	testTable := []struct {
+		name   string
+		accept string
+		want   []string
+	}{
+		{
+			name:   "empty header accepts anything",
+			accept: "",
+			want:   []string{"*/*"},
+		},
+		{
+			name:   "sorted by quality",
+			accept: "application/json;q=0.5, text/csv, */*;q=0.1",
+			want:   []string{"text/csv", "application/json", "*/*"},
+		},
+		{
+			name:   "specific range wins on the same quality",
+			accept: "*/*, text/*, application/msgpack",
+			want:   []string{"application/msgpack", "text/*", "*/*"},
+		},
+		{
+			name:   "q=0 and malformed ranges are left out",
+			accept: "text/csv;q=0, application/json;q=abc, application/msgpack",
+			want:   []string{"application/msgpack"},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, accepted := range parseAccept(test.accept) {
+				got = append(got, accepted.mediaType)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	list := &params.ListProductsResponses{}
+	suggest := &params.SuggestProductsResponse{}
+
+	mediaType := func(accept string, res any) string {
+		enc, ok := negotiate(accept, res)
+		if !ok {
+			return ""
+		}
+		return enc.mediaType
+	}
+
+	assert.Equal(t, MediaTypeJSON, mediaType("", list))
+	assert.Equal(t, MediaTypeCSV, mediaType("text/csv", list))
+	assert.Equal(t, MediaTypeCSV, mediaType("text/*", list))
+	assert.Equal(t, MediaTypeMsgpack, mediaType("application/json;q=0.9, application/msgpack", list))
+	// suggestions have no CSV form, the next accepted media type is used
+	assert.Equal(t, MediaTypeMsgpack, mediaType("text/csv, application/msgpack;q=0.5", suggest))
+	assert.Equal(t, "", mediaType("text/csv", suggest))
+}
+
+func TestSuccess_Not_Acceptable(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/product/suggest?q=me", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	Success(w, r, http.StatusOK, &params.SuggestProductsResponse{})
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+	assert.Equal(t, "media type text/csv not acceptable, supported media types are application/json, application/msgpack", resBody.Error)
+}
+
+func TestProductHandler_SuggestProductsHandler_Not_Acceptable(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	routes := NewRoutes(NewProductHandler(mockProductService), nil, nil, nil, nil)
+
+	// the service is not called for a response that cannot be sent
+	r := httptest.NewRequest(http.MethodGet, "/product/suggest?q=me", nil)
+	r.Header.Set("Accept", "text/csv")
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+	assert.Equal(t, "media type text/csv not acceptable, supported media types are application/json, application/msgpack", resBody.Error)
+}
+
+func TestProductHandler_ListProductsHandler_Content_Negotiation(t *testing.T) {
+	resMock := &params.ListProductsResponses{
+		TotalData: 1,
+		TotalPage: 1,
+		Products: []params.ProductResponse{
+			{
+				ID:         1,
+				Name:       "semangka",
+				Price:      1,
+				Currency:   "IDR",
+				FinalPrice: 1,
+				Type:       "buah",
+				CreatedAt:  time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		mc := gomock.NewController(t)
+		mockProductService := mockhandler.NewMockProductService(mc)
+		routes := NewRoutes(NewProductHandler(mockProductService), nil, nil, nil, nil)
+		mockProductService.EXPECT().ListProducts(gomock.Any(), gomock.Any()).Return(resMock, nil)
+
+		r := httptest.NewRequest(http.MethodGet, "/product", nil)
+		r.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, MediaTypeCSV, res.Header.Get("Content-Type"))
+		assert.Equal(t, "Accept", res.Header.Get("Vary"))
+		assert.Equal(t, "id,name,sku,barcode,price,final_price,currency,type,created_at,deleted_at\n1,semangka,,,1,1,IDR,buah,2026-10-01T08:00:00Z,\n", string(body))
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		mc := gomock.NewController(t)
+		mockProductService := mockhandler.NewMockProductService(mc)
+		routes := NewRoutes(NewProductHandler(mockProductService), nil, nil, nil, nil)
+		mockProductService.EXPECT().ListProducts(gomock.Any(), gomock.Any()).Return(resMock, nil)
+
+		r := httptest.NewRequest(http.MethodGet, "/product", nil)
+		r.Header.Set("Accept", "application/msgpack")
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, MediaTypeMsgpack, res.Header.Get("Content-Type"))
+
+		resBody := struct {
+			Data params.ListProductsResponses `msgpack:"data"`
+		}{}
+		decoder := msgpack.NewDecoder(res.Body)
+		decoder.SetCustomStructTag("json")
+		assert.NoError(t, decoder.Decode(&resBody))
+		assert.Equal(t, 1, resBody.Data.TotalData)
+		assert.Equal(t, "semangka", resBody.Data.Products[0].Name)
+	})
+
+	t.Run("not acceptable", func(t *testing.T) {
+		mc := gomock.NewController(t)
+		mockProductService := mockhandler.NewMockProductService(mc)
+		routes := NewRoutes(NewProductHandler(mockProductService), nil, nil, nil, nil)
+
+		r := httptest.NewRequest(http.MethodGet, "/product", nil)
+		r.Header.Set("Accept", "application/xml")
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		defer res.Body.Close()
+		resBody := mockErrorResBody
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+		assert.Equal(t, "media type application/xml not acceptable, supported media types are application/json, text/csv, application/msgpack", resBody.Error)
+	})
+}
@@ -3,22 +3,84 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
 	errs "github.com/elangreza/lion-superindo/pkg/error"
 )
 
-func NewRoutes(productHandler *ProductHandler) *http.ServeMux {
+func NewRoutes(productHandler *ProductHandler, productTypeHandler *ProductTypeHandler, promotionHandler *PromotionHandler, storeHandler *StoreHandler, reservationHandler *ReservationHandler) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.HandleFunc("/product", productHandler.ProductHandler)
+	mux.HandleFunc("/product", acceptable(productHandler.ProductHandler))
+	mux.HandleFunc("/product/suggest", acceptable(productHandler.SuggestProductsHandler, anyResponse...))
+	mux.HandleFunc("/product/bulk", acceptable(productHandler.BulkCreateProductsHandler, anyResponse...))
+	// the report format is chosen by its report param
+	mux.HandleFunc("/product/import", productHandler.ImportProductsHandler)
+	// the export format is chosen by its format param
+	mux.HandleFunc("/product/export", productHandler.ExportProductsHandler)
+	mux.HandleFunc("/product/{id}", acceptable(productHandler.ProductDetailHandler))
+	// ServeMux rejects /product/barcode/{code} next to /product/{id}/prices,
+	// both match /product/barcode/prices, so they share one pattern and the
+	// action is picked from this table
+	mux.HandleFunc("/product/{id}/{action}", productActions(acceptable(productHandler.GetProductByBarcodeHandler), map[string]http.HandlerFunc{
+		"restore":          acceptable(productHandler.RestoreProductHandler),
+		"prices":           acceptable(productHandler.ProductPricesHandler, anyResponse...),
+		"scheduled-prices": acceptable(productHandler.ScheduledPricesHandler, anyResponse...),
+		"variants":         acceptable(productHandler.VariantsHandler, anyResponse...),
+	}))
+	mux.HandleFunc("/product/{id}/scheduled-prices/{scheduled_id}", acceptable(productHandler.CancelScheduledPriceHandler, anyResponse...))
+	mux.HandleFunc("/product/{id}/variants/{variant_id}", acceptable(productHandler.VariantDetailHandler, anyResponse...))
+	mux.HandleFunc("/product-type", acceptable(productTypeHandler.ProductTypeHandler, anyResponse...))
+	mux.HandleFunc("/product-type/{name}", acceptable(productTypeHandler.ProductTypeDetailHandler, anyResponse...))
+	mux.HandleFunc("/product-type/{name}/parent", acceptable(productTypeHandler.MoveProductTypeHandler, anyResponse...))
+	mux.HandleFunc("/promotion", acceptable(promotionHandler.PromotionHandler, anyResponse...))
+	mux.HandleFunc("/promotion/{id}", acceptable(promotionHandler.PromotionDetailHandler, anyResponse...))
+	mux.HandleFunc("/store", acceptable(storeHandler.StoreHandler, anyResponse...))
+	mux.HandleFunc("/store/{id}", acceptable(storeHandler.StoreDetailHandler, anyResponse...))
+	mux.HandleFunc("/store/{id}/inventory", acceptable(storeHandler.InventoryHandler, anyResponse...))
+	mux.HandleFunc("/reservation", acceptable(reservationHandler.CreateReservationHandler, anyResponse...))
+	mux.HandleFunc("/reservation/{id}", acceptable(reservationHandler.GetReservationHandler, anyResponse...))
+	mux.HandleFunc("/reservation/{id}/confirm", acceptable(reservationHandler.ConfirmReservationHandler, anyResponse...))
+	mux.HandleFunc("/reservation/{id}/cancel", acceptable(reservationHandler.CancelReservationHandler, anyResponse...))
 	return mux
 }
 
-func Success(w http.ResponseWriter, status int, res any) {
-	w.Header().Add("Content-Type", "application/json")
+// productActions serves /product/barcode/{code} with barcode, and the other
+// /product/{id}/{action} paths with the handler of their action.
+func productActions(barcode http.HandlerFunc, actions map[string]http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "barcode" {
+			r.SetPathValue("code", r.PathValue("action"))
+			barcode(w, r)
+			return
+		}
+
+		action, ok := actions[r.PathValue("action")]
+		if !ok {
+			Error(w, http.StatusNotFound, errs.NotFoundError{Message: fmt.Sprintf("path %s", r.URL.Path)})
+			return
+		}
+
+		action(w, r)
+	}
+}
+
+// Success writes res in the media type negotiated from the Accept header of r.
+func Success(w http.ResponseWriter, r *http.Request, status int, res any) {
+	accept := r.Header.Get("Accept")
+	enc, ok := negotiate(accept, res)
+	if !ok {
+		Error(w, http.StatusNotAcceptable, notAcceptable(accept, res))
+		return
+	}
+
+	w.Header().Add("Content-Type", enc.mediaType)
+	w.Header().Add("Vary", "Accept")
 	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]any{"data": res})
+	if err := enc.encoder.Encode(w, res); err != nil {
+		slog.Error("controller", "encode", err.Error())
+	}
 }
 
 type APIError struct {
@@ -32,6 +94,14 @@ func Error(w http.ResponseWriter, status int, err error) {
 		slog.Error("controller", "service", err.Error())
 		status = errs.AlreadyExistError{}.HttpStatusCode()
 		apiErr.Message = err.Error()
+	case errors.As(err, &errs.ConflictError{}):
+		slog.Error("controller", "service", err.Error())
+		status = errs.ConflictError{}.HttpStatusCode()
+		apiErr.Message = err.Error()
+	case errors.As(err, &errs.NotFoundError{}):
+		slog.Error("controller", "service", err.Error())
+		status = errs.NotFoundError{}.HttpStatusCode()
+		apiErr.Message = err.Error()
 	case errors.As(err, &errs.ValidationError{}):
 		slog.Error("controller", "request", err.Error())
 		status = errs.ValidationError{}.HttpStatusCode()
@@ -40,6 +110,10 @@ func Error(w http.ResponseWriter, status int, err error) {
 		slog.Error("controller", "request", err.Error())
 		status = errs.MethodNotAllowedError{}.HttpStatusCode()
 		apiErr.Message = err.Error()
+	case errors.As(err, &errs.NotAcceptableError{}):
+		slog.Error("controller", "request", err.Error())
+		status = errs.NotAcceptableError{}.HttpStatusCode()
+		apiErr.Message = err.Error()
 	default:
 		slog.Error("controller", "service", err.Error())
 		status = http.StatusInternalServerError
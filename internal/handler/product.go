@@ -5,8 +5,13 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/elangreza/lion-superindo/internal/params"
 	errs "github.com/elangreza/lion-superindo/pkg/error"
@@ -16,6 +21,24 @@ type (
 	ProductService interface {
 		ListProducts(ctx context.Context, args params.ListProductsQueryParams) (*params.ListProductsResponses, error)
 		CreateProduct(ctx context.Context, req params.CreateProductRequest) (*params.CreateProductResponse, error)
+		BulkCreateProducts(ctx context.Context, req params.BulkCreateProductsRequest) (*params.BulkCreateProductsResponse, error)
+		ImportProducts(ctx context.Context, src io.Reader) (*params.ImportProductsResponse, error)
+		ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(params.ProductResponse) error) error
+		GetProduct(ctx context.Context, id int) (*params.ProductResponse, error)
+		GetProductByBarcode(ctx context.Context, barcode string) (*params.ProductResponse, error)
+		GetProductPrices(ctx context.Context, id int) (*params.ProductPricesResponse, error)
+		ScheduleProductPrice(ctx context.Context, id int, req params.ScheduleProductPriceRequest) (*params.ScheduledPriceResponse, error)
+		ListScheduledPrices(ctx context.Context, id int) ([]params.ScheduledPriceResponse, error)
+		CancelScheduledPrice(ctx context.Context, id, scheduledID int) error
+		ListVariants(ctx context.Context, productID int) ([]params.VariantResponse, error)
+		CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*params.VariantResponse, error)
+		UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*params.VariantResponse, error)
+		DeleteVariant(ctx context.Context, productID, id int) error
+		UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*params.ProductResponse, error)
+		PatchProduct(ctx context.Context, id int, req params.PatchProductRequest) (*params.ProductResponse, error)
+		DeleteProduct(ctx context.Context, id int) error
+		RestoreProduct(ctx context.Context, id int) (*params.ProductResponse, error)
+		SuggestProducts(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error)
 	}
 
 	ProductHandler struct {
@@ -34,15 +57,31 @@ func NewProductHandler(svc ProductService) *ProductHandler {
 //	@Tags			product
 //	@Accept			json
 //	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/msgpack
 //	@Success		200	{object}	params.ListProductsResponses
 //	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		406	{object}	handler.APIError	"none of the accepted media types is supported"
 //	@Failure		500	{object}	handler.APIError	"server error"
 //	@Router			/product [get]
-//	@Param			page	query	int			false	"Page number, default 1"
-//	@Param			limit	query	int			false	"Limit number of products, default 10"
-//	@Param			search	query	string		false	"Search by product name or id"
-//	@Param			type	query	[]string	false	"Filter by product type. Repeat param for multiple values (e.g. type=buah&type=snack) or use comma-separated (type=buah,snack)."
-//	@Param			sort	query	[]string	false	"Sort by field. Values can be created_at:asc, created_at:desc, price:asc, price:desc, name:asc, name:desc, id:asc, id:desc. Default: id:asc"
+//	@Param			page				query	int			false	"Page number, default 1"
+//	@Param			limit				query	int			false	"Limit number of products, default 10"
+//	@Param			search				query	string		false	"Search by product name or id"
+//	@Param			search_mode			query	string		false	"How search matches the name, by substring when empty. fulltext matches whole words and fuzzy tolerates typos, both rank the results and require search"	Enums(fulltext, fuzzy)
+//	@Param			min_similarity		query	number		false	"Lowest trigram similarity of the name in fuzzy search mode, between 0 and 1, default 0.3"																	minimum(0)	maximum(1)
+//	@Param			include_deleted		query	bool		false	"Include soft deleted products, default false"
+//	@Param			type				query	[]string	false	"Filter by product type. Repeat param for multiple values (e.g. type=buah&type=snack) or use comma-separated (type=buah,snack)."
+//	@Param			include_subtypes	query	bool		false	"Also match the descendants of type in the category tree, default false"
+//	@Param			currency			query	string		false	"Filter by ISO 4217 currency, IDR by default for min_price, max_price and the price sort"
+//	@Param			min_price			query	int			false	"Lowest price in the minor units of currency, inclusive"
+//	@Param			max_price			query	int			false	"Highest price in the minor units of currency, inclusive"
+//	@Param			created_after		query	string		false	"Only return the products created at or after this time (RFC3339 or YYYY-MM-DD)"
+//	@Param			created_before		query	string		false	"Only return the products created before this time (RFC3339 or YYYY-MM-DD)"
+//	@Param			as_of				query	string		false	"Return the prices as they were at this time (RFC3339 or YYYY-MM-DD), products created later are left out"
+//	@Param			in_stock_at			query	int			false	"Only return the products with stock at this store id"
+//	@Param			variant				query	[]string	false	"Only return the products with a variant having every given attribute, as name:value (e.g. variant=flavor:chocolate&variant=size:6)"
+//	@Param			facets				query	[]string	false	"Facets to count for the current filters, only type is supported. The type facet ignores the type filter"
+//	@Param			sort				query	[]string	false	"Sort by field. Values can be created_at:asc, created_at:desc, price:asc, price:desc, name:asc, name:desc, id:asc, id:desc, and relevance:asc, relevance:desc in fulltext and fuzzy search mode. Default: id:asc, or relevance:desc in fulltext and fuzzy search mode"
 func (ph *ProductHandler) ListProductsHandler(w http.ResponseWriter, r *http.Request) {
 	var err error
 	query := &params.ListProductsQueryParams{}
@@ -63,9 +102,13 @@ func (ph *ProductHandler) ListProductsHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	query.Search = r.URL.Query().Get("search")
-	query.Types = r.URL.Query()["type"]
-	query.Sorts = r.URL.Query()["sort"]
+	if err := productFilters(r, query); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	query.Cursor = r.URL.Query().Get("cursor")
+	query.Facets = r.URL.Query()["facets"]
 	if err := query.Validate(); err != nil {
 		Error(w, http.StatusBadRequest, err)
 		return
@@ -77,7 +120,88 @@ func (ph *ProductHandler) ListProductsHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	Success(w, http.StatusOK, res)
+	Success(w, r, http.StatusOK, res)
+}
+
+// productFilters reads the filter and sort params shared by the list and export endpoints.
+func productFilters(r *http.Request, query *params.ListProductsQueryParams) error {
+	var err error
+
+	if r.URL.Query().Get("include_deleted") != "" {
+		query.IncludeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid include_deleted"}
+		}
+	}
+
+	if r.URL.Query().Get("include_subtypes") != "" {
+		query.IncludeSubtypes, err = strconv.ParseBool(r.URL.Query().Get("include_subtypes"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid include_subtypes"}
+		}
+	}
+
+	if r.URL.Query().Get("min_price") != "" {
+		price, err := strconv.Atoi(r.URL.Query().Get("min_price"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid min_price"}
+		}
+		query.MinPrice = &price
+	}
+
+	if r.URL.Query().Get("max_price") != "" {
+		price, err := strconv.Atoi(r.URL.Query().Get("max_price"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid max_price"}
+		}
+		query.MaxPrice = &price
+	}
+
+	if r.URL.Query().Get("created_after") != "" {
+		date, err := parseTime(r.URL.Query().Get("created_after"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid created_after"}
+		}
+		query.CreatedAfter = &date
+	}
+
+	if r.URL.Query().Get("created_before") != "" {
+		date, err := parseTime(r.URL.Query().Get("created_before"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid created_before"}
+		}
+		query.CreatedBefore = &date
+	}
+
+	if r.URL.Query().Get("as_of") != "" {
+		date, err := parseTime(r.URL.Query().Get("as_of"))
+		if err != nil {
+			return errs.ValidationError{Message: "not valid as_of"}
+		}
+		query.AsOf = &date
+	}
+
+	if r.URL.Query().Get("in_stock_at") != "" {
+		query.InStockAt, err = strconv.Atoi(r.URL.Query().Get("in_stock_at"))
+		if err != nil || query.InStockAt < 1 {
+			return errs.ValidationError{Message: "not valid in_stock_at"}
+		}
+	}
+
+	if r.URL.Query().Get("min_similarity") != "" {
+		query.MinSimilarity, err = strconv.ParseFloat(r.URL.Query().Get("min_similarity"), 64)
+		if err != nil {
+			return errs.ValidationError{Message: "not valid min_similarity"}
+		}
+	}
+
+	query.Search = r.URL.Query().Get("search")
+	query.SearchMode = r.URL.Query().Get("search_mode")
+	query.Currency = r.URL.Query().Get("currency")
+	query.Types = r.URL.Query()["type"]
+	query.Variants = r.URL.Query()["variant"]
+	query.Sorts = r.URL.Query()["sort"]
+	return nil
 }
 
 // CreateProductHandler godoc
@@ -111,7 +235,429 @@ func (ph *ProductHandler) CreateProductHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	Success(w, http.StatusCreated, res)
+	Success(w, r, http.StatusCreated, res)
+}
+
+// BulkCreateProductsHandler godoc
+//
+//	@Summary		Create products in bulk
+//	@Description	Create up to 1000 products at once. Every product is validated on its own and the result of each one is returned in the same order.
+//	@Description	By default nothing is created when any product is rejected, with partial=true the valid products are still created.
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.BulkCreateProductsResponse	"every product is created"
+//	@Success		207	{object}	params.BulkCreateProductsResponse	"some products are created, only with partial=true"
+//	@Failure		400	{object}	params.BulkCreateProductsResponse	"no product is created, see the error of each product"
+//	@Failure		500	{object}	handler.APIError					"server error"
+//	@Router			/product/bulk [post]
+//	@Param			partial	query	bool							false	"Create the valid products even when others are rejected, default false"
+//	@Param			body	body	[]params.CreateProductRequest	true	"Products data"
+func (ph *ProductHandler) BulkCreateProductsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	body := params.BulkCreateProductsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body.Products); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if r.URL.Query().Get("partial") != "" {
+		var err error
+		body.Partial, err = strconv.ParseBool(r.URL.Query().Get("partial"))
+		if err != nil {
+			Error(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid partial"})
+			return
+		}
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.BulkCreateProducts(r.Context(), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch {
+	case res.Failed == 0:
+		Success(w, r, http.StatusCreated, res)
+	case res.Created > 0:
+		Success(w, r, http.StatusMultiStatus, res)
+	default:
+		Success(w, r, http.StatusBadRequest, res)
+	}
+}
+
+// maxImportSize limits the size of an import upload.
+const maxImportSize = 50 << 20
+
+// importTimeout replaces the server read and write timeouts of an import, so a
+// large upload is not cut off while its batches are upserted.
+const importTimeout = 5 * time.Minute
+
+// ImportProductsHandler godoc
+//
+//	@Summary		Import products from CSV
+//	@Description	Upsert products from a CSV file with name, price and type columns, the header row is optional. A product with the same name is updated.
+//	@Description	Rejected rows are returned with their line number, with report=csv they are returned as a downloadable CSV report.
+//	@Tags			product
+//	@Accept			mpfd
+//	@Produce		json
+//	@Produce		text/csv
+//	@Success		200	{object}	params.ImportProductsResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/import [post]
+//	@Param			file	formData	file	true	"CSV file"
+//	@Param			report	query		string	false	"Return the rejected rows as a CSV report"	Enums(csv)
+func (ph *ProductHandler) ImportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	report := r.URL.Query().Get("report")
+	if report != "" && report != "csv" {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid report"})
+		return
+	}
+	// without a report the result has no CSV form
+	if report == "" {
+		if err := accepts(r, anyResponse); err != nil {
+			Error(w, http.StatusNotAcceptable, err)
+			return
+		}
+	}
+
+	deadline := time.Now().Add(importTimeout)
+	rc := http.NewResponseController(w)
+	if err := errors.Join(rc.SetReadDeadline(deadline), rc.SetWriteDeadline(deadline)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// the parts are read as they arrive, so the file is never buffered
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	var file *multipart.Part
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+			return
+		}
+		if part.FormName() == "file" {
+			file = part
+			break
+		}
+	}
+
+	if file == nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: "file cannot be empty"})
+		return
+	}
+
+	res, err := ph.svc.ImportProducts(r.Context(), file)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if report == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="import-report.csv"`)
+		w.WriteHeader(http.StatusOK)
+		if err := res.WriteReport(w); err != nil {
+			slog.Error("controller", "report", err.Error())
+		}
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// GetProductHandler godoc
+//
+//	@Summary		Get product
+//	@Description	Get a single product by id
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/msgpack
+//	@Success		200	{object}	params.ProductResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		406	{object}	handler.APIError	"none of the accepted media types is supported"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id} [get]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) GetProductHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.GetProduct(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// GetProductByBarcodeHandler godoc
+//
+//	@Summary		Get product by barcode
+//	@Description	Get a single product by its EAN-13 or UPC-A barcode, as scanned at the till
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Produce		text/csv
+//	@Produce		application/msgpack
+//	@Success		200	{object}	params.ProductResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		406	{object}	handler.APIError	"none of the accepted media types is supported"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/barcode/{code} [get]
+//	@Param			code	path	string	true	"EAN-13 or UPC-A barcode"
+func (ph *ProductHandler) GetProductByBarcodeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	barcode, err := params.ParseBarcode(r.PathValue("code"))
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.GetProductByBarcode(r.Context(), barcode)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// UpdateProductHandler godoc
+//
+//	@Summary		Replace product
+//	@Description	Replace name, price and type of a product
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if another product with same name already exists"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id} [put]
+//	@Param			id		path	int							true	"Product id"
+//	@Param			body	body	params.UpdateProductRequest	true	"Product data"
+func (ph *ProductHandler) UpdateProductHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.UpdateProductRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.UpdateProduct(r.Context(), id, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// PatchProductHandler godoc
+//
+//	@Summary		Patch product
+//	@Description	Partially update a product using JSON merge patch
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if another product with same name already exists"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id} [patch]
+//	@Param			id		path	int							true	"Product id"
+//	@Param			body	body	params.PatchProductRequest	true	"Fields to change"
+func (ph *ProductHandler) PatchProductHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.PatchProductRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		var validationErr errs.ValidationError
+		if !errors.As(err, &validationErr) {
+			validationErr = errs.ValidationError{Message: err.Error()}
+		}
+		Error(w, http.StatusBadRequest, validationErr)
+		return
+	}
+
+	res, err := ph.svc.PatchProduct(r.Context(), id, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// DeleteProductHandler godoc
+//
+//	@Summary		Delete product
+//	@Description	Soft delete a product. It can be brought back with the restore endpoint
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id} [delete]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ph.svc.DeleteProduct(r.Context(), id); err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreProductHandler godoc
+//
+//	@Summary		Restore product
+//	@Description	Restore a soft deleted product
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"deleted product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/restore [post]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) RestoreProductHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.RestoreProduct(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// SuggestProductsHandler godoc
+//
+//	@Summary		Suggest products
+//	@Description	Autocomplete product names and product types starting with the given prefix
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.SuggestProductsResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/suggest [get]
+//	@Param			q		query	string	true	"Prefix typed by the user"
+//	@Param			limit	query	int		false	"Max suggestions of each kind, default 5, max 20"
+func (ph *ProductHandler) SuggestProductsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	var err error
+	query := &params.SuggestProductsQueryParams{}
+
+	if r.URL.Query().Get("limit") != "" {
+		query.Limit, err = strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			Error(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid limit"})
+			return
+		}
+	}
+
+	query.Query = r.URL.Query().Get("q")
+	if err := query.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.SuggestProducts(r.Context(), *query)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
 }
 
 func (ph *ProductHandler) ProductHandler(w http.ResponseWriter, r *http.Request) {
@@ -126,3 +672,38 @@ func (ph *ProductHandler) ProductHandler(w http.ResponseWriter, r *http.Request)
 		})
 	}
 }
+
+func (ph *ProductHandler) ProductDetailHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ph.GetProductHandler(w, r)
+	case http.MethodPut:
+		ph.UpdateProductHandler(w, r)
+	case http.MethodPatch:
+		ph.PatchProductHandler(w, r)
+	case http.MethodDelete:
+		ph.DeleteProductHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func productID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		return 0, errs.ValidationError{Message: "not valid id"}
+	}
+
+	return id, nil
+}
+
+// parseTime accepts RFC3339 timestamps or plain dates (YYYY-MM-DD, midnight UTC).
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
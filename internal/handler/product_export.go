@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/xuri/excelize/v2"
+)
+
+type (
+	// productExporter encodes the exported products one by one. Flush must be
+	// called once every product is written.
+	productExporter interface {
+		Export(product params.ProductResponse) error
+		Flush() error
+		io.Closer
+	}
+
+	exportFormat struct {
+		contentType string
+		newExporter func(w io.Writer) (productExporter, error)
+	}
+)
+
+var exportFormats = map[string]exportFormat{
+	params.ExportFormatCSV: {
+		contentType: "text/csv",
+		newExporter: newCSVExporter,
+	},
+	params.ExportFormatNDJSON: {
+		contentType: "application/x-ndjson",
+		newExporter: newNDJSONExporter,
+	},
+	params.ExportFormatXLSX: {
+		contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		newExporter: newXLSXExporter,
+	},
+}
+
+// ExportProductsHandler godoc
+//
+//	@Summary		Export products
+//	@Description	Download every product matching the filters as a file, without pagination. The filters are the same as the list endpoint.
+//	@Tags			product
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/export [get]
+//	@Param			format				query	string		false	"File format, default csv"	Enums(csv, ndjson, xlsx)
+//	@Param			search				query	string		false	"Search by product name or id"
+//	@Param			search_mode			query	string		false	"How search matches the name, by substring when empty. fulltext matches whole words and fuzzy tolerates typos, both rank the results and require search"	Enums(fulltext, fuzzy)
+//	@Param			min_similarity		query	number		false	"Lowest trigram similarity of the name in fuzzy search mode, between 0 and 1, default 0.3"																	minimum(0)	maximum(1)
+//	@Param			include_deleted		query	bool		false	"Include soft deleted products, default false"
+//	@Param			type				query	[]string	false	"Filter by product type. Repeat param for multiple values (e.g. type=buah&type=snack) or use comma-separated (type=buah,snack)."
+//	@Param			include_subtypes	query	bool		false	"Also match the descendants of type in the category tree, default false"
+//	@Param			currency			query	string		false	"Filter by ISO 4217 currency, IDR by default for min_price, max_price and the price sort"
+//	@Param			min_price			query	int			false	"Lowest price in the minor units of currency, inclusive"
+//	@Param			max_price			query	int			false	"Highest price in the minor units of currency, inclusive"
+//	@Param			created_after		query	string		false	"Only export the products created at or after this time (RFC3339 or YYYY-MM-DD)"
+//	@Param			created_before		query	string		false	"Only export the products created before this time (RFC3339 or YYYY-MM-DD)"
+//	@Param			as_of				query	string		false	"Export the prices as they were at this time (RFC3339 or YYYY-MM-DD), products created later are left out"
+//	@Param			in_stock_at			query	int			false	"Only export the products with stock at this store id"
+//	@Param			variant				query	[]string	false	"Only export the products with a variant having every given attribute, as name:value"
+//	@Param			sort				query	[]string	false	"Sort by field, same values as the list endpoint. Default: id:asc, or relevance:desc in fulltext and fuzzy search mode"
+func (ph *ProductHandler) ExportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	name := r.URL.Query().Get("format")
+	if name == "" {
+		name = params.ExportFormatCSV
+	}
+
+	format, ok := exportFormats[name]
+	if !ok {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid format"})
+		return
+	}
+
+	query := &params.ListProductsQueryParams{}
+	if err := productFilters(r, query); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := query.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// a full catalog dump streams for longer than the server WriteTimeout,
+	// the client disconnecting still cancels it through the request context
+	err := http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := &exportResponseWriter{
+		w:           w,
+		contentType: format.contentType,
+		filename:    "products." + name,
+	}
+	exporter, err := format.newExporter(out)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer exporter.Close()
+
+	err = ph.svc.ExportProducts(r.Context(), *query, exporter.Export)
+	if err == nil {
+		err = exporter.Flush()
+	}
+
+	if err != nil {
+		if !out.started {
+			Error(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		// the status is already sent, abort so the client does not take
+		// the truncated file as complete
+		slog.Error("controller", "service", err.Error())
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// exportResponseWriter sends the download headers with the first write, so an
+// error happening before any row is encoded can still be sent as JSON.
+type exportResponseWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (erw *exportResponseWriter) Write(p []byte) (int, error) {
+	if !erw.started {
+		erw.w.Header().Set("Content-Type", erw.contentType)
+		erw.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, erw.filename))
+		erw.w.WriteHeader(http.StatusOK)
+		erw.started = true
+	}
+
+	return erw.w.Write(p)
+}
+
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func newCSVExporter(w io.Writer) (productExporter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(params.ExportProductsHeader); err != nil {
+		return nil, err
+	}
+
+	return &csvExporter{writer: writer}, nil
+}
+
+func (ce *csvExporter) Export(product params.ProductResponse) error {
+	return ce.writer.Write(product.ExportRecord())
+}
+
+func (ce *csvExporter) Flush() error {
+	ce.writer.Flush()
+	return ce.writer.Error()
+}
+
+func (ce *csvExporter) Close() error {
+	return nil
+}
+
+type ndjsonExporter struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONExporter(w io.Writer) (productExporter, error) {
+	buf := bufio.NewWriter(w)
+	return &ndjsonExporter{buf: buf, encoder: json.NewEncoder(buf)}, nil
+}
+
+func (ne *ndjsonExporter) Export(product params.ProductResponse) error {
+	// Encode ends every value with a newline
+	return ne.encoder.Encode(product)
+}
+
+func (ne *ndjsonExporter) Flush() error {
+	return ne.buf.Flush()
+}
+
+func (ne *ndjsonExporter) Close() error {
+	return nil
+}
+
+const exportSheet = "Sheet1"
+
+// xlsxExporter writes the rows with the excelize stream writer, which keeps
+// them in a temporary file instead of memory. The workbook is a zip archive,
+// so it is only sent once complete.
+type xlsxExporter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXExporter(w io.Writer) (productExporter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(exportSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	xe := &xlsxExporter{w: w, file: file, stream: stream}
+	header := make([]any, 0, len(params.ExportProductsHeader))
+	for _, column := range params.ExportProductsHeader {
+		header = append(header, column)
+	}
+	if err := xe.setRow(header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return xe, nil
+}
+
+func (xe *xlsxExporter) setRow(values []any) error {
+	xe.row++
+	return xe.stream.SetRow("A"+strconv.Itoa(xe.row), values)
+}
+
+func (xe *xlsxExporter) Export(product params.ProductResponse) error {
+	record := product.ExportRecord()
+	row := make([]any, len(record))
+	for i, column := range params.ExportProductsHeader {
+		// keep id and prices as numbers so they can be used in formulas
+		switch column {
+		case "id":
+			row[i] = product.ID
+		case "price":
+			row[i] = product.Price
+		case "final_price":
+			row[i] = product.FinalPrice
+		default:
+			row[i] = record[i]
+		}
+	}
+
+	return xe.setRow(row)
+}
+
+func (xe *xlsxExporter) Flush() error {
+	if err := xe.stream.Flush(); err != nil {
+		return err
+	}
+
+	return xe.file.Write(xe.w)
+}
+
+// Close removes the temporary files of the workbook.
+func (xe *xlsxExporter) Close() error {
+	return xe.file.Close()
+}
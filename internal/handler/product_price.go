@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// ProductPricesHandler godoc
+//
+//	@Summary		Get product price history
+//	@Description	Get every price of a product with the time it took effect, the latest price first
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductPricesResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/prices [get]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) ProductPricesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.GetProductPrices(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// ListScheduledPricesHandler godoc
+//
+//	@Summary		Get scheduled prices
+//	@Description	Get the price changes of a product which are not applied yet, the next one first
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.ScheduledPriceResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/scheduled-prices [get]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) ListScheduledPricesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.ListScheduledPrices(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// ScheduleProductPriceHandler godoc
+//
+//	@Summary		Schedule price change
+//	@Description	Change the price of a product at a future time. The change is applied by the price scheduler, and recorded in the price history from effective_at
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.ScheduledPriceResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/scheduled-prices [post]
+//	@Param			id		path	int									true	"Product id"
+//	@Param			body	body	params.ScheduleProductPriceRequest	true	"New price and the time it takes effect"
+func (ph *ProductHandler) ScheduleProductPriceHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.ScheduleProductPriceRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.ScheduleProductPrice(r.Context(), id, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// CancelScheduledPriceHandler godoc
+//
+//	@Summary		Cancel scheduled price change
+//	@Description	Cancel a price change which is not applied yet
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"scheduled price not found or already applied"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/scheduled-prices/{scheduled_id} [delete]
+//	@Param			id				path	int	true	"Product id"
+//	@Param			scheduled_id	path	int	true	"Scheduled price id"
+func (ph *ProductHandler) CancelScheduledPriceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	scheduledID, err := strconv.Atoi(r.PathValue("scheduled_id"))
+	if err != nil || scheduledID < 1 {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: "not valid scheduled_id"})
+		return
+	}
+
+	if err := ph.svc.CancelScheduledPrice(r.Context(), id, scheduledID); err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ph *ProductHandler) ScheduledPricesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ph.ListScheduledPricesHandler(w, r)
+	case http.MethodPost:
+		ph.ScheduleProductPriceHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
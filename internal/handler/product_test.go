@@ -2,9 +2,11 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,7 +14,9 @@ import (
 
 	"github.com/elangreza/lion-superindo/internal/params"
 	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
 	"go.uber.org/mock/gomock"
 )
 
@@ -27,7 +31,7 @@ func TestProductHandler_ProductHandler_Invalid_Method(t *testing.T) {
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 	routes.ServeHTTP(w, r)
 
 	res := w.Result()
@@ -46,7 +50,7 @@ func TestProductHandler_ListProductsHandler_Error_When_Validate_Query_Params(t *
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 
 	r := httptest.NewRequest(http.MethodGet, "/product?sort=test", nil)
 	w := httptest.NewRecorder()
@@ -68,7 +72,7 @@ func TestProductHandler_ListProductsHandler_Error_When_Processing_ListProducts(t
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 	mockProductService.EXPECT().ListProducts(gomock.Any(), gomock.Any()).Return(nil, errors.New("test"))
 
 	r := httptest.NewRequest(http.MethodGet, "/product", nil)
@@ -91,7 +95,7 @@ func TestProductHandler_ListProductsHandler_Success(t *testing.T) {
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 	resMock := &params.ListProductsResponses{
 		TotalData: 1,
 		TotalPage: 1,
@@ -131,7 +135,7 @@ func TestProductHandler_CreateProductHandler_Error_When_Validate_Query(t *testin
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 
 	reqBody := params.CreateProductRequest{
 		Name:  "a",
@@ -161,7 +165,7 @@ func TestProductHandler_CreateProductHandler_Error_When_Processing_CreateProduct
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 
 	reqBody := params.CreateProductRequest{
 		Name:  "a",
@@ -193,7 +197,7 @@ func TestProductHandler_CreateProductHandler_Success(t *testing.T) {
 	mc := gomock.NewController(t)
 	mockProductService := mockhandler.NewMockProductService(mc)
 	ph := NewProductHandler(mockProductService)
-	routes := NewRoutes(ph)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
 
 	reqBody := params.CreateProductRequest{
 		Name:  "a",
@@ -225,3 +229,1126 @@ func TestProductHandler_CreateProductHandler_Success(t *testing.T) {
 	assert.Equal(t, resBodya.Data.ID, int(1))
 
 }
+
+func TestProductHandler_BulkCreateProductsHandler_Error_When_Empty(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product/bulk", bytes.NewBufferString(`[]`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation error: products cannot be empty", resBody.Error)
+}
+
+func TestProductHandler_BulkCreateProductsHandler_Status(t *testing.T) {
+	testTable := []struct {
+		name   string
+		url    string
+		res    *params.BulkCreateProductsResponse
+		status int
+	}{
+		{
+			name:   "every product is created",
+			url:    "/product/bulk",
+			res:    &params.BulkCreateProductsResponse{Created: 2},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "some products are created",
+			url:    "/product/bulk?partial=true",
+			res:    &params.BulkCreateProductsResponse{Created: 1, Failed: 1},
+			status: http.StatusMultiStatus,
+		},
+		{
+			name:   "no product is created",
+			url:    "/product/bulk",
+			res:    &params.BulkCreateProductsResponse{Failed: 1},
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mockProductService := mockhandler.NewMockProductService(mc)
+			ph := NewProductHandler(mockProductService)
+			routes := NewRoutes(ph, nil, nil, nil, nil)
+
+			mockProductService.EXPECT().BulkCreateProducts(gomock.Any(), gomock.Any()).Return(test.res, nil)
+
+			reqBody := `[{"name":"a","price":1,"type":"a"},{"name":"b","price":1,"type":"a"}]`
+			r := httptest.NewRequest(http.MethodPost, test.url, bytes.NewBufferString(reqBody))
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, test.status, res.StatusCode)
+
+			resBody := struct {
+				Data params.BulkCreateProductsResponse `json:"data"`
+			}{}
+			err = json.Unmarshal(body, &resBody)
+			assert.NoError(t, err)
+			assert.Equal(t, test.res.Created, resBody.Data.Created)
+		})
+	}
+}
+
+func TestProductHandler_ImportProductsHandler_Error_When_File_Is_Missing(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("note", "weekly prices")
+	writer.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/product/import", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: file cannot be empty", resBody.Error)
+}
+
+func TestProductHandler_ImportProductsHandler_Report(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "prices.csv")
+	assert.NoError(t, err)
+	part.Write([]byte("melon,1000,buah\n,1000,buah\n"))
+	writer.Close()
+
+	mockProductService.EXPECT().ImportProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, src io.Reader) (*params.ImportProductsResponse, error) {
+			content, err := io.ReadAll(src)
+			assert.NoError(t, err)
+			assert.Equal(t, "melon,1000,buah\n,1000,buah\n", string(content))
+			return &params.ImportProductsResponse{
+				Created: 1,
+				Rejected: []params.ImportRejectedRow{
+					{Line: 2, Record: []string{"", "1000", "buah"}, Error: "validation error: name cannot be empty"},
+				},
+			}, nil
+		})
+
+	r := httptest.NewRequest(http.MethodPost, "/product/import?report=csv", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	report, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/csv", res.Header.Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="import-report.csv"`, res.Header.Get("Content-Disposition"))
+	assert.Equal(t, "line,name,price,type,currency,error\n2,,1000,buah,,validation error: name cannot be empty\n", string(report))
+}
+
+func TestProductHandler_ImportProductsHandler_Outlasts_Server_Timeouts(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	srv := httptest.NewUnstartedServer(NewRoutes(ph, nil, nil, nil, nil))
+	srv.Config.ReadTimeout = 50 * time.Millisecond
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "prices.csv")
+	assert.NoError(t, err)
+	part.Write([]byte("melon,1000,buah\n"))
+	writer.Close()
+
+	mockProductService.EXPECT().ImportProducts(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, src io.Reader) (*params.ImportProductsResponse, error) {
+			// a slow import, past both server timeouts
+			time.Sleep(100 * time.Millisecond)
+			_, err := io.ReadAll(src)
+			assert.NoError(t, err)
+			return &params.ImportProductsResponse{Created: 1, Rejected: []params.ImportRejectedRow{}}, nil
+		})
+
+	res, err := http.Post(srv.URL+"/product/import", writer.FormDataContentType(), body)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	resBody := struct {
+		Data params.ImportProductsResponse `json:"data"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 1, resBody.Data.Created)
+}
+
+func TestProductHandler_ExportProductsHandler_Error_When_Format_Is_Invalid(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/export?format=pdf", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: not valid format", resBody.Error)
+}
+
+func TestProductHandler_ExportProductsHandler_Error_When_Processing_ExportProducts(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	mockProductService.EXPECT().ExportProducts(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+
+	r := httptest.NewRequest(http.MethodGet, "/product/export", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+	assert.Equal(t, "server error", resBody.Error)
+}
+
+func TestProductHandler_ExportProductsHandler_Success(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	products := []params.ProductResponse{
+		{ID: 1, Name: "Melon, Madu", SKU: "BUAH-001", Barcode: "4006381333931", Price: 1000, Currency: "IDR", FinalPrice: 900, Type: "buah", CreatedAt: createdAt},
+		{ID: 2, Name: "Apel", Price: 2000, Currency: "IDR", FinalPrice: 2000, Type: "buah", CreatedAt: createdAt},
+	}
+
+	testTable := []struct {
+		name        string
+		url         string
+		contentType string
+		filename    string
+		body        string
+	}{
+		{
+			name:        "csv by default",
+			url:         "/product/export?type=buah&sort=price:desc",
+			contentType: "text/csv",
+			filename:    "products.csv",
+			body: "id,name,sku,barcode,price,final_price,currency,type,created_at,deleted_at\n" +
+				"1,\"Melon, Madu\",BUAH-001,4006381333931,1000,900,IDR,buah,2026-10-01T08:00:00Z,\n" +
+				"2,Apel,,,2000,2000,IDR,buah,2026-10-01T08:00:00Z,\n",
+		},
+		{
+			name:        "ndjson",
+			url:         "/product/export?format=ndjson&type=buah&sort=price:desc",
+			contentType: "application/x-ndjson",
+			filename:    "products.ndjson",
+			body: `{"id":1,"name":"Melon, Madu","sku":"BUAH-001","barcode":"4006381333931","price":1000,"currency":"IDR","final_price":900,"type":"buah","created_at":"2026-10-01T08:00:00Z"}` + "\n" +
+				`{"id":2,"name":"Apel","price":2000,"currency":"IDR","final_price":2000,"type":"buah","created_at":"2026-10-01T08:00:00Z"}` + "\n",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mockProductService := mockhandler.NewMockProductService(mc)
+			ph := NewProductHandler(mockProductService)
+			routes := NewRoutes(ph, nil, nil, nil, nil)
+
+			mockProductService.EXPECT().ExportProducts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req params.ListProductsQueryParams, fn func(params.ProductResponse) error) error {
+					assert.Equal(t, []string{"buah"}, req.Types)
+					assert.Equal(t, "IDR", req.Currency)
+					assert.Equal(t, []params.Sort{{Key: "price", Direction: "desc"}, {Key: "id", Direction: "asc"}}, req.GetSorts())
+					for _, product := range products {
+						if err := fn(product); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			r := httptest.NewRequest(http.MethodGet, test.url, nil)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, test.contentType, res.Header.Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="`+test.filename+`"`, res.Header.Get("Content-Disposition"))
+			assert.Equal(t, test.body, string(body))
+		})
+	}
+}
+
+func TestProductHandler_ExportProductsHandler_XLSX(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	createdAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mockProductService.EXPECT().ExportProducts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ params.ListProductsQueryParams, fn func(params.ProductResponse) error) error {
+			return fn(params.ProductResponse{
+				ID: 1, Name: "Melon", SKU: "BUAH-001", Barcode: "4006381333931", Price: 1000, Currency: "IDR", FinalPrice: 900, Type: "buah", CreatedAt: createdAt,
+			})
+		})
+
+	r := httptest.NewRequest(http.MethodGet, "/product/export?format=xlsx", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	file, err := excelize.OpenReader(res.Body)
+	assert.NoError(t, err)
+	defer file.Close()
+	rows, err := file.GetRows(exportSheet)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		params.ExportProductsHeader,
+		{"1", "Melon", "BUAH-001", "4006381333931", "1000", "900", "IDR", "buah", "2026-10-01T08:00:00Z"},
+	}, rows)
+}
+
+func TestProductHandler_ExportProductsHandler_Outlasts_Server_Write_Timeout(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	srv := httptest.NewUnstartedServer(NewRoutes(ph, nil, nil, nil, nil))
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	createdAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mockProductService.EXPECT().ExportProducts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ params.ListProductsQueryParams, fn func(params.ProductResponse) error) error {
+			for id := 1; id <= 2; id++ {
+				// a slow page of a large catalog, past the server write timeout
+				time.Sleep(50 * time.Millisecond)
+				if err := fn(params.ProductResponse{ID: id, Name: "Apel", Price: 2000, Currency: "IDR", FinalPrice: 2000, Type: "buah", CreatedAt: createdAt}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	res, err := http.Get(srv.URL + "/product/export?format=ndjson")
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `{"id":1,"name":"Apel","price":2000,"currency":"IDR","final_price":2000,"type":"buah","created_at":"2026-10-01T08:00:00Z"}`+"\n"+
+		`{"id":2,"name":"Apel","price":2000,"currency":"IDR","final_price":2000,"type":"buah","created_at":"2026-10-01T08:00:00Z"}`+"\n", string(body))
+}
+
+func TestProductHandler_GetProductHandler_Error_When_Validate_ID(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/abc", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "validation error: not valid id")
+}
+
+func TestProductHandler_GetProductHandler_Error_When_Not_Found(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().GetProduct(gomock.Any(), 1).Return(nil, errs.NotFoundError{Message: "product 1"})
+
+	r := httptest.NewRequest(http.MethodGet, "/product/1", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "product 1 not found")
+}
+
+func TestProductHandler_GetProductHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().GetProduct(gomock.Any(), 1).Return(&params.ProductResponse{
+		ID:        1,
+		Name:      "semangka",
+		Price:     1,
+		Type:      "buah",
+		CreatedAt: time.Now(),
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/1", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Data.ID, 1)
+	assert.Equal(t, resBody.Data.Name, "semangka")
+}
+
+func TestProductHandler_GetProductByBarcodeHandler_Error_When_Validate_Barcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr string
+	}{
+		{
+			name:    "wrong check digit",
+			url:     "/product/barcode/4006381333932",
+			wantErr: "validation error: 4006381333932 not valid barcode, check digit should be 1",
+		},
+		{
+			name:    "wrong length",
+			url:     "/product/barcode/40063813339",
+			wantErr: "validation error: 40063813339 not valid barcode, expected 13 digits EAN-13 or 12 digits UPC-A",
+		},
+		{
+			name:    "not only digits",
+			url:     "/product/barcode/prices",
+			wantErr: "validation error: prices not valid barcode, it can only have digits",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mc := gomock.NewController(t)
+			mockProductService := mockhandler.NewMockProductService(mc)
+			ph := NewProductHandler(mockProductService)
+			routes := NewRoutes(ph, nil, nil, nil, nil)
+
+			r := httptest.NewRequest(http.MethodGet, test.url, nil)
+			w := httptest.NewRecorder()
+			routes.ServeHTTP(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+			resBody := mockErrorResBody
+			err = json.Unmarshal(body, &resBody)
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantErr, resBody.Error)
+		})
+	}
+}
+
+func TestProductHandler_GetProductByBarcodeHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	// the UPC-A is looked up as its EAN-13 form
+	mockProductService.EXPECT().GetProductByBarcode(gomock.Any(), "0036000291452").Return(&params.ProductResponse{
+		ID:        1,
+		Name:      "semangka",
+		Barcode:   "0036000291452",
+		Price:     1,
+		Type:      "buah",
+		CreatedAt: time.Now(),
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/barcode/036000291452", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resBody.Data.ID)
+	assert.Equal(t, "0036000291452", resBody.Data.Barcode)
+}
+
+func TestProductHandler_ProductActions_Unknown_Action(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/1/unknown", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "path /product/1/unknown not found", resBody.Error)
+}
+
+func TestProductHandler_ProductPricesHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	changedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	mockProductService.EXPECT().GetProductPrices(gomock.Any(), 1).Return(&params.ProductPricesResponse{
+		ProductID: 1,
+		Prices: []params.ProductPriceResponse{
+			{Price: 1500, Currency: "IDR", EffectiveFrom: changedAt},
+			{Price: 1000, Currency: "IDR", EffectiveFrom: createdAt, EffectiveTo: &changedAt},
+		},
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/1/prices", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.JSONEq(t, `{"data":{"product_id":1,"prices":[`+
+		`{"price":1500,"currency":"IDR","effective_from":"2026-10-01T08:00:00Z"},`+
+		`{"price":1000,"currency":"IDR","effective_from":"2026-01-01T08:00:00Z","effective_to":"2026-10-01T08:00:00Z"}]}}`, string(body))
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_As_Of_Is_Invalid(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product?as_of=yesterday", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: not valid as_of", resBody.Error)
+}
+
+func TestProductHandler_ScheduleProductPriceHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product/1/scheduled-prices", bytes.NewBufferString(`{"price":2500,"effective_at":"2020-01-01T00:00:00Z"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: effective_at must be in the future", resBody.Error)
+}
+
+func TestProductHandler_ScheduleProductPriceHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	effectiveAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	req := params.ScheduleProductPriceRequest{Price: 2500, EffectiveAt: effectiveAt}
+	mockProductService.EXPECT().ScheduleProductPrice(gomock.Any(), 1, req).Return(&params.ScheduledPriceResponse{
+		ID: 3, ProductID: 1, Price: 2500, EffectiveAt: effectiveAt,
+	}, nil)
+
+	body, err := json.Marshal(req)
+	assert.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, "/product/1/scheduled-prices", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := struct {
+		Data params.ScheduledPriceResponse `json:"data"`
+	}{}
+	err = json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, 3, resBody.Data.ID)
+}
+
+func TestProductHandler_CancelScheduledPriceHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	mockProductService.EXPECT().CancelScheduledPrice(gomock.Any(), 1, 3).Return(nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/product/1/scheduled-prices/3", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestProductHandler_UpdateProductHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	reqBody := params.UpdateProductRequest{
+		Name:  "a",
+		Price: 1,
+	}
+	payload, _ := json.Marshal(reqBody)
+
+	r := httptest.NewRequest(http.MethodPut, "/product/1", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "validation error: type cannot be empty")
+}
+
+func TestProductHandler_UpdateProductHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	reqBody := params.UpdateProductRequest{
+		Name:  "a",
+		Price: 1,
+		Type:  "Buah",
+	}
+	payload, _ := json.Marshal(reqBody)
+
+	// the omitted currency is left empty, so the stored one is kept
+	mockProductService.EXPECT().UpdateProduct(gomock.Any(), 1, params.UpdateProductRequest{
+		Name:  "a",
+		Price: 1,
+		Type:  "buah",
+	}).Return(&params.ProductResponse{ID: 1, Name: "a", Price: 1, Type: "buah"}, nil)
+
+	r := httptest.NewRequest(http.MethodPut, "/product/1", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Data.Type, "buah")
+}
+
+func TestProductHandler_PatchProductHandler_Error_When_Field_Is_Null(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPatch, "/product/1", bytes.NewReader([]byte(`{"name":null}`)))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "validation error: name cannot be null")
+}
+
+func TestProductHandler_PatchProductHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	price := 2000
+	mockProductService.EXPECT().PatchProduct(gomock.Any(), 1, params.PatchProductRequest{
+		Price: &price,
+	}).Return(&params.ProductResponse{ID: 1, Name: "a", Price: 2000, Type: "buah"}, nil)
+
+	r := httptest.NewRequest(http.MethodPatch, "/product/1", bytes.NewReader([]byte(`{"price":2000}`)))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Data.Price, 2000)
+}
+
+func TestProductHandler_DeleteProductHandler_Error_When_Not_Found(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().DeleteProduct(gomock.Any(), 1).Return(errs.NotFoundError{Message: "product 1"})
+
+	r := httptest.NewRequest(http.MethodDelete, "/product/1", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestProductHandler_DeleteProductHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().DeleteProduct(gomock.Any(), 1).Return(nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/product/1", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestProductHandler_RestoreProductHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/1/restore", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestProductHandler_RestoreProductHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().RestoreProduct(gomock.Any(), 1).Return(&params.ProductResponse{ID: 1, Name: "a"}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product/1/restore", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Data.ID, 1)
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_Sort_Key_Duplicated(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product?sort=price:asc,name:desc&sort=price:desc", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "validation error: price is duplicated sort key")
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_Validate_Range_Filters(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	testTable := []struct {
+		url string
+		err string
+	}{
+		{url: "/product?min_price=a", err: "validation error: not valid min_price"},
+		{url: "/product?min_price=2000&max_price=1000", err: "validation error: min_price cannot be greater than max_price"},
+		{url: "/product?currency=XYZ", err: "validation error: XYZ not valid currency"},
+		{url: "/product?created_after=yesterday", err: "validation error: not valid created_after"},
+		{url: "/product?created_after=2025-02-01&created_before=2025-01-01", err: "validation error: created_after must be before created_before"},
+		{url: "/product?in_stock_at=kemang", err: "validation error: not valid in_stock_at"},
+		{url: "/product?in_stock_at=0", err: "validation error: not valid in_stock_at"},
+	}
+
+	for _, test := range testTable {
+		r := httptest.NewRequest(http.MethodGet, test.url, nil)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		resBody := mockErrorResBody
+		err = json.Unmarshal(body, &resBody)
+		assert.NoError(t, err)
+		assert.Equal(t, resBody.Error, test.err)
+	}
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_Validate_Search_Mode(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	testTable := []struct {
+		url string
+		err string
+	}{
+		{url: "/product?search=kopi&search_mode=regex", err: "validation error: regex not valid search mode"},
+		{url: "/product?search_mode=fulltext", err: "validation error: search cannot be empty in fulltext search mode"},
+		{url: "/product?search=kopi&sort=relevance:desc", err: "validation error: relevance sort requires a ranked search mode"},
+		{url: "/product?facets=type,price", err: "validation error: price not valid facet"},
+		{url: "/product?include_subtypes=true", err: "validation error: include_subtypes requires type"},
+	}
+
+	for _, test := range testTable {
+		r := httptest.NewRequest(http.MethodGet, test.url, nil)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		resBody := mockErrorResBody
+		err = json.Unmarshal(body, &resBody)
+		assert.NoError(t, err)
+		assert.Equal(t, resBody.Error, test.err)
+	}
+}
+
+func TestProductHandler_SuggestProductsHandler_Error_When_Validate_Query(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/suggest?q=%20", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, resBody.Error, "validation error: q cannot be empty")
+}
+
+func TestProductHandler_SuggestProductsHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+	mockProductService.EXPECT().SuggestProducts(gomock.Any(), params.SuggestProductsQueryParams{
+		Query: "sa",
+		Limit: 5,
+	}).Return(&params.SuggestProductsResponse{
+		Products: []string{"Sawi"},
+		Types:    []string{"sayuran"},
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product/suggest?q=Sa", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.SuggestProductsResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sawi"}, resBody.Data.Products)
+	assert.Equal(t, []string{"sayuran"}, resBody.Data.Types)
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_Variant_Is_Invalid(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product?variant=chocolate", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: chocolate not valid variant, expected name:value", resBody.Error)
+}
+
+func TestProductHandler_CreateVariantHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product/1/variants", bytes.NewBufferString(`{"attributes":{},"price":2500}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: attributes cannot be empty", resBody.Error)
+}
+
+func TestProductHandler_CreateVariantHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	mockProductService.EXPECT().CreateVariant(gomock.Any(), 1, params.CreateVariantRequest{
+		Attributes: map[string]string{"flavor": "chocolate", "size": "6"},
+		Price:      12000,
+		SKU:        "DONAT-CHO-6",
+	}).Return(&params.VariantResponse{
+		ID:         2,
+		SKU:        "DONAT-CHO-6",
+		Attributes: map[string]string{"flavor": "chocolate", "size": "6"},
+		Price:      12000,
+		Currency:   "IDR",
+		FinalPrice: 12000,
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product/1/variants", bytes.NewBufferString(`{"attributes":{"Flavor":"Chocolate","size":"6"},"price":12000,"sku":"donat-cho-6"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := struct {
+		Data params.VariantResponse `json:"data"`
+	}{}
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Equal(t, 2, resBody.Data.ID)
+	assert.Equal(t, "chocolate", resBody.Data.Attributes["flavor"])
+}
+
+func TestProductHandler_DeleteVariantHandler_Error_When_Validate_Variant_ID(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/product/1/variants/a", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	resBody := mockErrorResBody
+	err := json.NewDecoder(res.Body).Decode(&resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "validation error: not valid variant_id", resBody.Error)
+}
+
+func TestProductHandler_DeleteVariantHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	mockProductService.EXPECT().DeleteVariant(gomock.Any(), 1, 2).Return(nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/product/1/variants/2", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
+
+func TestProductHandler_ListProductsHandler_Error_When_Cursor_Does_Not_Match_Sort(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductService := mockhandler.NewMockProductService(mc)
+	ph := NewProductHandler(mockProductService)
+	routes := NewRoutes(ph, nil, nil, nil, nil)
+
+	cursor := params.EncodeCursor([]params.Sort{{Key: "price", Direction: "asc"}, {Key: "id", Direction: "asc"}}, []string{"1000", "5"})
+	testTable := []string{
+		"/product?sort=price:desc&cursor=" + cursor,
+		"/product?sort=name:asc&cursor=" + cursor,
+		"/product?cursor=" + cursor,
+	}
+
+	for _, url := range testTable {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, r)
+
+		res := w.Result()
+		resBody := mockErrorResBody
+		err := json.NewDecoder(res.Body).Decode(&resBody)
+		res.Body.Close()
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, "validation error: cursor does not match the requested sort", resBody.Error)
+	}
+}
@@ -0,0 +1,216 @@
+package handler
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/handler/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	ProductTypeService interface {
+		ListProductTypes(ctx context.Context) ([]params.ProductTypeResponse, error)
+		CreateProductType(ctx context.Context, req params.CreateProductTypeRequest) (*params.ProductTypeResponse, error)
+		RenameProductType(ctx context.Context, name string, req params.RenameProductTypeRequest) (*params.ProductTypeResponse, error)
+		MoveProductType(ctx context.Context, name string, req params.MoveProductTypeRequest) (*params.ProductTypeResponse, error)
+		DeleteProductType(ctx context.Context, name string) error
+	}
+
+	ProductTypeHandler struct {
+		svc ProductTypeService
+	}
+)
+
+func NewProductTypeHandler(svc ProductTypeService) *ProductTypeHandler {
+	return &ProductTypeHandler{svc: svc}
+}
+
+// ListProductTypesHandler godoc
+//
+//	@Summary		Get product types
+//	@Description	Get all product types with their parent and the number of products of each type and of its whole subtree
+//	@Tags			product-type
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.ProductTypeResponse
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product-type [get]
+func (pth *ProductTypeHandler) ListProductTypesHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := pth.svc.ListProductTypes(r.Context())
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// CreateProductTypeHandler godoc
+//
+//	@Summary		Create product type
+//	@Description	Create a new product type, optionally under a parent category
+//	@Tags			product-type
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.ProductTypeResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if product type already exists"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product-type [post]
+//	@Param			body	body	params.CreateProductTypeRequest	true	"Product type data"
+func (pth *ProductTypeHandler) CreateProductTypeHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.CreateProductTypeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := pth.svc.CreateProductType(r.Context(), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// RenameProductTypeHandler godoc
+//
+//	@Summary		Rename product type
+//	@Description	Rename a product type, its products are moved to the new name
+//	@Tags			product-type
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductTypeResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product type not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if another product type with the new name already exists"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product-type/{name} [patch]
+//	@Param			name	path	string							true	"Product type name"
+//	@Param			body	body	params.RenameProductTypeRequest	true	"New name"
+func (pth *ProductTypeHandler) RenameProductTypeHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.RenameProductTypeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := pth.svc.RenameProductType(r.Context(), productTypeName(r), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// MoveProductTypeHandler godoc
+//
+//	@Summary		Move product type
+//	@Description	Move a product type with all of its descendants under another parent, an empty parent makes it a root category
+//	@Tags			product-type
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ProductTypeResponse
+//	@Failure		400	{object}	handler.APIError	"validation error, if the parent does not exist or is a descendant of the type"
+//	@Failure		404	{object}	handler.APIError	"product type not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product-type/{name}/parent [put]
+//	@Param			name	path	string							true	"Product type name"
+//	@Param			body	body	params.MoveProductTypeRequest	true	"New parent"
+func (pth *ProductTypeHandler) MoveProductTypeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	body := params.MoveProductTypeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := pth.svc.MoveProductType(r.Context(), productTypeName(r), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// DeleteProductTypeHandler godoc
+//
+//	@Summary		Delete product type
+//	@Description	Delete a product type. It is refused while it has sub types or products, soft deleted ones included, still use the type
+//	@Tags			product-type
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		404	{object}	handler.APIError	"product type not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if the type has sub types or products still use it"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product-type/{name} [delete]
+//	@Param			name	path	string	true	"Product type name"
+func (pth *ProductTypeHandler) DeleteProductTypeHandler(w http.ResponseWriter, r *http.Request) {
+	if err := pth.svc.DeleteProductType(r.Context(), productTypeName(r)); err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (pth *ProductTypeHandler) ProductTypeHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pth.ListProductTypesHandler(w, r)
+	case http.MethodPost:
+		pth.CreateProductTypeHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func (pth *ProductTypeHandler) ProductTypeDetailHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPatch:
+		pth.RenameProductTypeHandler(w, r)
+	case http.MethodDelete:
+		pth.DeleteProductTypeHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+// productTypeName reads the type from the path, types are stored lowercase.
+func productTypeName(r *http.Request) string {
+	return strings.ToLower(r.PathValue("name"))
+}
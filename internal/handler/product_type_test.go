@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductTypeHandler_ProductTypeHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPut, "/product-type", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestProductTypeHandler_ListProductTypesHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().ListProductTypes(gomock.Any()).Return([]params.ProductTypeResponse{
+		{Name: "buah", TotalProducts: 2},
+	}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product-type", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data []params.ProductTypeResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "buah", resBody.Data[0].Name)
+	assert.Equal(t, 2, resBody.Data[0].TotalProducts)
+}
+
+func TestProductTypeHandler_CreateProductTypeHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product-type", bytes.NewBufferString(`{"name":" "}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation error: name cannot be empty", resBody.Error)
+}
+
+func TestProductTypeHandler_CreateProductTypeHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().
+		CreateProductType(gomock.Any(), params.CreateProductTypeRequest{Name: "minuman"}).
+		Return(&params.ProductTypeResponse{Name: "minuman"}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/product-type", bytes.NewBufferString(`{"name":"Minuman"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+}
+
+func TestProductTypeHandler_RenameProductTypeHandler_Error_When_Already_Exist(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().
+		RenameProductType(gomock.Any(), "sayurab", params.RenameProductTypeRequest{Name: "sayuran"}).
+		Return(nil, errs.AlreadyExistError{Message: "product type sayuran"})
+
+	r := httptest.NewRequest(http.MethodPatch, "/product-type/Sayurab", bytes.NewBufferString(`{"name":"sayuran"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+}
+
+func TestProductTypeHandler_RenameProductTypeHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().
+		RenameProductType(gomock.Any(), "sayurab", params.RenameProductTypeRequest{Name: "sayuran"}).
+		Return(&params.ProductTypeResponse{Name: "sayuran", TotalProducts: 3}, nil)
+
+	r := httptest.NewRequest(http.MethodPatch, "/product-type/sayurab", bytes.NewBufferString(`{"name":"sayuran"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductTypeResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "sayuran", resBody.Data.Name)
+}
+
+func TestProductTypeHandler_MoveProductTypeHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/product-type/apel/parent", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestProductTypeHandler_MoveProductTypeHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().
+		MoveProductType(gomock.Any(), "apel", params.MoveProductTypeRequest{Parent: "buah impor"}).
+		Return(&params.ProductTypeResponse{Name: "apel", Parent: "buah impor", SubtreeProducts: 3}, nil)
+
+	r := httptest.NewRequest(http.MethodPut, "/product-type/apel/parent", bytes.NewBufferString(`{"parent":"Buah Impor"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	resBody := struct {
+		Data params.ProductTypeResponse `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "buah impor", resBody.Data.Parent)
+}
+
+func TestProductTypeHandler_DeleteProductTypeHandler_Error_When_Still_Used(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().DeleteProductType(gomock.Any(), "buah").
+		Return(errs.ConflictError{Message: "product type buah is still used by 2 products"})
+
+	r := httptest.NewRequest(http.MethodDelete, "/product-type/buah", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "product type buah is still used by 2 products", resBody.Error)
+}
+
+func TestProductTypeHandler_DeleteProductTypeHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockProductTypeService := mockhandler.NewMockProductTypeService(mc)
+	pth := NewProductTypeHandler(mockProductTypeService)
+	routes := NewRoutes(nil, pth, nil, nil, nil)
+	mockProductTypeService.EXPECT().DeleteProductType(gomock.Any(), "buah").Return(nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/product-type/buah", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
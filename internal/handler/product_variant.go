@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// ListVariantsHandler godoc
+//
+//	@Summary		Get product variants
+//	@Description	Get the variants of a product with their final prices
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.VariantResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/variants [get]
+//	@Param			id	path	int	true	"Product id"
+func (ph *ProductHandler) ListVariantsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.ListVariants(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// CreateVariantHandler godoc
+//
+//	@Summary		Create product variant
+//	@Description	Add a variant, such as a flavor or a pack size, to a product. The currency defaults to the product currency
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.VariantResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product not found"
+//	@Failure		409	{object}	handler.APIError	"variant or sku already exist"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/variants [post]
+//	@Param			id		path	int							true	"Product id"
+//	@Param			body	body	params.CreateVariantRequest	true	"Variant data"
+func (ph *ProductHandler) CreateVariantHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.CreateVariantRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.CreateVariant(r.Context(), id, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// UpdateVariantHandler godoc
+//
+//	@Summary		Update product variant
+//	@Description	Replace the attributes, price and sku of a variant
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.VariantResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"product or variant not found"
+//	@Failure		409	{object}	handler.APIError	"variant or sku already exist"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/variants/{variant_id} [put]
+//	@Param			id			path	int							true	"Product id"
+//	@Param			variant_id	path	int							true	"Variant id"
+//	@Param			body		body	params.UpdateVariantRequest	true	"Variant data"
+func (ph *ProductHandler) UpdateVariantHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	variantID, err := variantID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.UpdateVariantRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := ph.svc.UpdateVariant(r.Context(), id, variantID, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// DeleteVariantHandler godoc
+//
+//	@Summary		Delete product variant
+//	@Description	Delete a variant of a product
+//	@Tags			product
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"variant not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/product/{id}/variants/{variant_id} [delete]
+//	@Param			id			path	int	true	"Product id"
+//	@Param			variant_id	path	int	true	"Variant id"
+func (ph *ProductHandler) DeleteVariantHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := productID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	variantID, err := variantID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := ph.svc.DeleteVariant(r.Context(), id, variantID); err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ph *ProductHandler) VariantsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ph.ListVariantsHandler(w, r)
+	case http.MethodPost:
+		ph.CreateVariantHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func (ph *ProductHandler) VariantDetailHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		ph.UpdateVariantHandler(w, r)
+	case http.MethodDelete:
+		ph.DeleteVariantHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func variantID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("variant_id"))
+	if err != nil || id < 1 {
+		return 0, errs.ValidationError{Message: "not valid variant_id"}
+	}
+
+	return id, nil
+}
@@ -0,0 +1,175 @@
+package handler
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/handler/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	PromotionService interface {
+		ListPromotions(ctx context.Context) ([]params.PromotionResponse, error)
+		GetPromotion(ctx context.Context, id int) (*params.PromotionResponse, error)
+		CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*params.PromotionResponse, error)
+		DeletePromotion(ctx context.Context, id int) error
+	}
+
+	PromotionHandler struct {
+		svc PromotionService
+	}
+)
+
+func NewPromotionHandler(svc PromotionService) *PromotionHandler {
+	return &PromotionHandler{svc: svc}
+}
+
+// ListPromotionsHandler godoc
+//
+//	@Summary		Get promotions
+//	@Description	Get all promotions, ended and upcoming ones included, the latest starting first
+//	@Tags			promotion
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.PromotionResponse
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/promotion [get]
+func (pmh *PromotionHandler) ListPromotionsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := pmh.svc.ListPromotions(r.Context())
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// CreatePromotionHandler godoc
+//
+//	@Summary		Create promotion
+//	@Description	Create a percentage or fixed discount for a product, a product type with its sub types, or the whole catalog when neither is set
+//	@Tags			promotion
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.PromotionResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/promotion [post]
+//	@Param			body	body	params.CreatePromotionRequest	true	"Promotion data"
+func (pmh *PromotionHandler) CreatePromotionHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.CreatePromotionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := pmh.svc.CreatePromotion(r.Context(), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// GetPromotionHandler godoc
+//
+//	@Summary		Get promotion
+//	@Description	Get promotion by id
+//	@Tags			promotion
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.PromotionResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"promotion not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/promotion/{id} [get]
+//	@Param			id	path	int	true	"Promotion id"
+func (pmh *PromotionHandler) GetPromotionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := promotionID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := pmh.svc.GetPromotion(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// DeletePromotionHandler godoc
+//
+//	@Summary		Delete promotion
+//	@Description	Delete a promotion, prices go back to the next best promotion right away
+//	@Tags			promotion
+//	@Accept			json
+//	@Produce		json
+//	@Success		204
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"promotion not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/promotion/{id} [delete]
+//	@Param			id	path	int	true	"Promotion id"
+func (pmh *PromotionHandler) DeletePromotionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := promotionID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := pmh.svc.DeletePromotion(r.Context(), id); err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (pmh *PromotionHandler) PromotionHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pmh.ListPromotionsHandler(w, r)
+	case http.MethodPost:
+		pmh.CreatePromotionHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func (pmh *PromotionHandler) PromotionDetailHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pmh.GetPromotionHandler(w, r)
+	case http.MethodDelete:
+		pmh.DeletePromotionHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func promotionID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		return 0, errs.ValidationError{Message: "not valid id"}
+	}
+
+	return id, nil
+}
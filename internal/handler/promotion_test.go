@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPromotionHandler_PromotionHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockPromotionService := mockhandler.NewMockPromotionService(mc)
+	pmh := NewPromotionHandler(mockPromotionService)
+	routes := NewRoutes(nil, nil, pmh, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPut, "/promotion", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestPromotionHandler_CreatePromotionHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockPromotionService := mockhandler.NewMockPromotionService(mc)
+	pmh := NewPromotionHandler(mockPromotionService)
+	routes := NewRoutes(nil, nil, pmh, nil, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/promotion", bytes.NewBufferString(
+		`{"name":"flash sale","discount_type":"percentage","discount_value":120,"starts_at":"2026-10-18T00:00:00Z","ends_at":"2026-10-19T00:00:00Z"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation error: percentage discount_value must be between 1 and 100", resBody.Error)
+}
+
+func TestPromotionHandler_CreatePromotionHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockPromotionService := mockhandler.NewMockPromotionService(mc)
+	pmh := NewPromotionHandler(mockPromotionService)
+	routes := NewRoutes(nil, nil, pmh, nil, nil)
+
+	startsAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	mockPromotionService.EXPECT().
+		CreatePromotion(gomock.Any(), params.CreatePromotionRequest{
+			Name: "flash sale", DiscountType: params.DiscountFixed, DiscountValue: 500, Currency: "IDR", ProductType: "buah", StartsAt: startsAt, EndsAt: endsAt,
+		}).
+		Return(&params.PromotionResponse{ID: 1, Name: "flash sale"}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/promotion", bytes.NewBufferString(
+		`{"name":"flash sale","discount_type":"Fixed","discount_value":500,"currency":"idr","product_type":"Buah","starts_at":"2026-10-18T00:00:00Z","ends_at":"2026-10-19T00:00:00Z"}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+}
+
+func TestPromotionHandler_GetPromotionHandler_Error_When_Not_Found(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockPromotionService := mockhandler.NewMockPromotionService(mc)
+	pmh := NewPromotionHandler(mockPromotionService)
+	routes := NewRoutes(nil, nil, pmh, nil, nil)
+	mockPromotionService.EXPECT().GetPromotion(gomock.Any(), 7).Return(nil, errs.NotFoundError{Message: "promotion 7"})
+
+	r := httptest.NewRequest(http.MethodGet, "/promotion/7", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestPromotionHandler_DeletePromotionHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockPromotionService := mockhandler.NewMockPromotionService(mc)
+	pmh := NewPromotionHandler(mockPromotionService)
+	routes := NewRoutes(nil, nil, pmh, nil, nil)
+	mockPromotionService.EXPECT().DeletePromotion(gomock.Any(), 7).Return(nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/promotion/7", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+}
@@ -0,0 +1,190 @@
+package handler
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/handler/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	ReservationService interface {
+		GetReservation(ctx context.Context, id int) (*params.ReservationResponse, error)
+		CreateReservation(ctx context.Context, req params.CreateReservationRequest) (*params.ReservationResponse, error)
+		ConfirmReservation(ctx context.Context, id int) (*params.ReservationResponse, error)
+		CancelReservation(ctx context.Context, id int) (*params.ReservationResponse, error)
+	}
+
+	ReservationHandler struct {
+		svc ReservationService
+	}
+)
+
+func NewReservationHandler(svc ReservationService) *ReservationHandler {
+	return &ReservationHandler{svc: svc}
+}
+
+// CreateReservationHandler godoc
+//
+//	@Summary		Create reservation
+//	@Description	Hold stock of a product at a store while a customer pays. The stock is taken out of the inventory and put back when the reservation is cancelled or expires
+//	@Tags			reservation
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.ReservationResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if the stock is lower than the quantity"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/reservation [post]
+//	@Param			body	body	params.CreateReservationRequest	true	"Reservation data"
+func (rh *ReservationHandler) CreateReservationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	body := params.CreateReservationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rh.svc.CreateReservation(r.Context(), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// GetReservationHandler godoc
+//
+//	@Summary		Get reservation
+//	@Description	Get reservation by id
+//	@Tags			reservation
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ReservationResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"reservation not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/reservation/{id} [get]
+//	@Param			id	path	int	true	"Reservation id"
+func (rh *ReservationHandler) GetReservationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := reservationID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rh.svc.GetReservation(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// ConfirmReservationHandler godoc
+//
+//	@Summary		Confirm reservation
+//	@Description	Confirm a pending reservation once the customer paid, the stock stays out of the inventory
+//	@Tags			reservation
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ReservationResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"reservation not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if the reservation is not pending or expired"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/reservation/{id}/confirm [post]
+//	@Param			id	path	int	true	"Reservation id"
+func (rh *ReservationHandler) ConfirmReservationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := reservationID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rh.svc.ConfirmReservation(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// CancelReservationHandler godoc
+//
+//	@Summary		Cancel reservation
+//	@Description	Cancel a pending reservation, the stock is put back in the inventory
+//	@Tags			reservation
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.ReservationResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"reservation not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if the reservation is not pending"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/reservation/{id}/cancel [post]
+//	@Param			id	path	int	true	"Reservation id"
+func (rh *ReservationHandler) CancelReservationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+		return
+	}
+
+	id, err := reservationID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rh.svc.CancelReservation(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+func reservationID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		return 0, errs.ValidationError{Message: "not valid id"}
+	}
+
+	return id, nil
+}
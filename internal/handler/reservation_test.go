@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReservationHandler_CreateReservationHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockReservationService := mockhandler.NewMockReservationService(mc)
+	rh := NewReservationHandler(mockReservationService)
+	routes := NewRoutes(nil, nil, nil, nil, rh)
+
+	r := httptest.NewRequest(http.MethodGet, "/reservation", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestReservationHandler_CreateReservationHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockReservationService := mockhandler.NewMockReservationService(mc)
+	rh := NewReservationHandler(mockReservationService)
+	routes := NewRoutes(nil, nil, nil, nil, rh)
+
+	r := httptest.NewRequest(http.MethodPost, "/reservation", bytes.NewBufferString(`{"store_id":1,"product_id":101,"quantity":1,"ttl_seconds":7200}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation error: ttl_seconds must be between 1 and 3600", resBody.Error)
+}
+
+func TestReservationHandler_CreateReservationHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockReservationService := mockhandler.NewMockReservationService(mc)
+	rh := NewReservationHandler(mockReservationService)
+	routes := NewRoutes(nil, nil, nil, nil, rh)
+	mockReservationService.EXPECT().
+		CreateReservation(gomock.Any(), params.CreateReservationRequest{StoreID: 1, ProductID: 101, Quantity: 2, TTL: params.DefaultReservationTTL}).
+		Return(&params.ReservationResponse{ID: 7, Status: params.ReservationPending}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/reservation", bytes.NewBufferString(`{"store_id":1,"product_id":101,"quantity":2}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+}
+
+func TestReservationHandler_ConfirmReservationHandler_Error_When_Expired(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockReservationService := mockhandler.NewMockReservationService(mc)
+	rh := NewReservationHandler(mockReservationService)
+	routes := NewRoutes(nil, nil, nil, nil, rh)
+	mockReservationService.EXPECT().ConfirmReservation(gomock.Any(), 7).Return(nil, errs.ConflictError{Message: "reservation 7 is expired"})
+
+	r := httptest.NewRequest(http.MethodPost, "/reservation/7/confirm", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+}
+
+func TestReservationHandler_CancelReservationHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockReservationService := mockhandler.NewMockReservationService(mc)
+	rh := NewReservationHandler(mockReservationService)
+	routes := NewRoutes(nil, nil, nil, nil, rh)
+	mockReservationService.EXPECT().CancelReservation(gomock.Any(), 7).
+		Return(&params.ReservationResponse{ID: 7, Status: params.ReservationCancelled}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/reservation/7/cancel", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
@@ -0,0 +1,231 @@
+package handler
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/handler/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	StoreService interface {
+		ListStores(ctx context.Context) ([]params.StoreResponse, error)
+		GetStore(ctx context.Context, id int) (*params.StoreResponse, error)
+		CreateStore(ctx context.Context, req params.CreateStoreRequest) (*params.StoreResponse, error)
+		ListStockLevels(ctx context.Context, storeID int) ([]params.StockLevelResponse, error)
+		AdjustStock(ctx context.Context, storeID int, req params.AdjustStockRequest) (*params.StockLevelResponse, error)
+	}
+
+	StoreHandler struct {
+		svc StoreService
+	}
+)
+
+func NewStoreHandler(svc StoreService) *StoreHandler {
+	return &StoreHandler{svc: svc}
+}
+
+// ListStoresHandler godoc
+//
+//	@Summary		Get stores
+//	@Description	Get all stores
+//	@Tags			store
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.StoreResponse
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/store [get]
+func (sh *StoreHandler) ListStoresHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := sh.svc.ListStores(r.Context())
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// CreateStoreHandler godoc
+//
+//	@Summary		Create store
+//	@Description	Create a store to keep stock at
+//	@Tags			store
+//	@Accept			json
+//	@Produce		json
+//	@Success		201	{object}	params.StoreResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if store with same name already exists"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/store [post]
+//	@Param			body	body	params.CreateStoreRequest	true	"Store data"
+func (sh *StoreHandler) CreateStoreHandler(w http.ResponseWriter, r *http.Request) {
+	body := params.CreateStoreRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := sh.svc.CreateStore(r.Context(), body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusCreated, res)
+}
+
+// GetStoreHandler godoc
+//
+//	@Summary		Get store
+//	@Description	Get store by id
+//	@Tags			store
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.StoreResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"store not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/store/{id} [get]
+//	@Param			id	path	int	true	"Store id"
+func (sh *StoreHandler) GetStoreHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := storeID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := sh.svc.GetStore(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// ListStockLevelsHandler godoc
+//
+//	@Summary		Get store inventory
+//	@Description	Get the stock of every product kept at a store, out of stock products included
+//	@Tags			store
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		params.StockLevelResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"store not found"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/store/{id}/inventory [get]
+//	@Param			id	path	int	true	"Store id"
+func (sh *StoreHandler) ListStockLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := storeID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := sh.svc.ListStockLevels(r.Context(), id)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+// AdjustStockHandler godoc
+//
+//	@Summary		Adjust stock
+//	@Description	Add delta to the stock of a product at a store, a negative delta takes it out. Concurrent adjustments are applied atomically
+//	@Tags			store
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	params.StockLevelResponse
+//	@Failure		400	{object}	handler.APIError	"validation error"
+//	@Failure		404	{object}	handler.APIError	"store not found"
+//	@Failure		409	{object}	handler.APIError	"conflict error, if the stock is lower than a negative delta"
+//	@Failure		500	{object}	handler.APIError	"server error"
+//	@Router			/store/{id}/inventory [post]
+//	@Param			id		path	int							true	"Store id"
+//	@Param			body	body	params.AdjustStockRequest	true	"Stock adjustment"
+func (sh *StoreHandler) AdjustStockHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := storeID(r)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := params.AdjustStockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		Error(w, http.StatusBadRequest, errs.ValidationError{Message: err.Error()})
+		return
+	}
+
+	if err := body.Validate(); err != nil {
+		Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := sh.svc.AdjustStock(r.Context(), id, body)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	Success(w, r, http.StatusOK, res)
+}
+
+func (sh *StoreHandler) StoreHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		sh.ListStoresHandler(w, r)
+	case http.MethodPost:
+		sh.CreateStoreHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func (sh *StoreHandler) StoreDetailHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		sh.GetStoreHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func (sh *StoreHandler) InventoryHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		sh.ListStockLevelsHandler(w, r)
+	case http.MethodPost:
+		sh.AdjustStockHandler(w, r)
+	default:
+		Error(w, http.StatusMethodNotAllowed, errs.MethodNotAllowedError{
+			Method: r.Method,
+		})
+	}
+}
+
+func storeID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		return 0, errs.ValidationError{Message: "not valid id"}
+	}
+
+	return id, nil
+}
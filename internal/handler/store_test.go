@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockhandler "github.com/elangreza/lion-superindo/mock/handler"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStoreHandler_StoreHandler_Invalid_Method(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockStoreService := mockhandler.NewMockStoreService(mc)
+	sh := NewStoreHandler(mockStoreService)
+	routes := NewRoutes(nil, nil, nil, sh, nil)
+
+	r := httptest.NewRequest(http.MethodDelete, "/store", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
+
+func TestStoreHandler_CreateStoreHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockStoreService := mockhandler.NewMockStoreService(mc)
+	sh := NewStoreHandler(mockStoreService)
+	routes := NewRoutes(nil, nil, nil, sh, nil)
+	mockStoreService.EXPECT().CreateStore(gomock.Any(), params.CreateStoreRequest{Name: "Kemang"}).
+		Return(&params.StoreResponse{ID: 1, Name: "Kemang"}, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/store", bytes.NewBufferString(`{"name":" Kemang "}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+}
+
+func TestStoreHandler_AdjustStockHandler_Error_When_Validate_Body(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockStoreService := mockhandler.NewMockStoreService(mc)
+	sh := NewStoreHandler(mockStoreService)
+	routes := NewRoutes(nil, nil, nil, sh, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/store/1/inventory", bytes.NewBufferString(`{"product_id":101,"delta":0}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	resBody := mockErrorResBody
+	err = json.Unmarshal(body, &resBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "validation error: delta cannot be zero", resBody.Error)
+}
+
+func TestStoreHandler_AdjustStockHandler_Error_When_Not_Enough_Stock(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockStoreService := mockhandler.NewMockStoreService(mc)
+	sh := NewStoreHandler(mockStoreService)
+	routes := NewRoutes(nil, nil, nil, sh, nil)
+	mockStoreService.EXPECT().AdjustStock(gomock.Any(), 1, params.AdjustStockRequest{ProductID: 101, Delta: -5}).
+		Return(nil, errs.ConflictError{Message: "not enough stock of product 101 at store 1"})
+
+	r := httptest.NewRequest(http.MethodPost, "/store/1/inventory", bytes.NewBufferString(`{"product_id":101,"delta":-5}`))
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+}
+
+func TestStoreHandler_ListStockLevelsHandler_Success(t *testing.T) {
+	mc := gomock.NewController(t)
+	mockStoreService := mockhandler.NewMockStoreService(mc)
+	sh := NewStoreHandler(mockStoreService)
+	routes := NewRoutes(nil, nil, nil, sh, nil)
+	mockStoreService.EXPECT().ListStockLevels(gomock.Any(), 1).
+		Return([]params.StockLevelResponse{{StoreID: 1, ProductID: 101, Quantity: 4}}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/store/1/inventory", nil)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, r)
+
+	res := w.Result()
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
@@ -1,23 +1,39 @@
 package params
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 )
 
+type Sort struct {
+	Key       string
+	Direction string
+}
+
 type PaginationParams struct {
 	Sorts []string
 	Limit int
 	Page  int
+	// opaque cursor returned as next_cursor. When set, keyset pagination is used instead of page
+	Cursor string
 
-	// local var. Used for sorting in the DB
-	sortMap map[string]string
+	// local var. Sorts in the order they must be applied in the DB
+	sortList []Sort
+	// local var. Decoded values of the cursor, one for each sort
+	cursorValues []string
 	// local var. Used for cache data ordering
 	orderingKey string
 }
 
 func (pqr *PaginationParams) Validate() error {
+	if pqr.Cursor != "" && pqr.Page > 1 {
+		return errors.New("page and cursor cannot be used together")
+	}
+
 	if pqr.Page < 1 {
 		pqr.Page = 1
 	}
@@ -26,45 +42,145 @@ func (pqr *PaginationParams) Validate() error {
 		pqr.Limit = 5
 	}
 
-	var orderingBuilder strings.Builder
-	if len(pqr.Sorts) > 0 {
-		newSorts := []string{}
-		for _, sort := range pqr.Sorts {
-			if strings.Contains(sort, ",") {
-				newSorts = append(newSorts, strings.Split(sort, ",")...)
-			} else {
-				newSorts = append(newSorts, sort)
-			}
+	pqr.sortList = nil
+
+	newSorts := []string{}
+	for _, sort := range pqr.Sorts {
+		if strings.Contains(sort, ",") {
+			newSorts = append(newSorts, strings.Split(sort, ",")...)
+		} else {
+			newSorts = append(newSorts, sort)
+		}
+	}
+
+	for _, sortRaw := range newSorts {
+		parts := strings.Split(sortRaw, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("%s is not valid sort format", sortRaw)
+		}
+
+		value := strings.ToLower(strings.TrimSpace(parts[0]))
+		direction := strings.ToLower(strings.TrimSpace(parts[1]))
+
+		if direction != "asc" && direction != "desc" {
+			return errors.New("not valid sort direction")
 		}
 
-		pqr.sortMap = make(map[string]string)
-		for _, sortRaw := range newSorts {
-			parts := strings.Split(sortRaw, ":")
-			if len(parts) != 2 {
-				return fmt.Errorf("%s is not valid sort format", sortRaw)
-			}
+		if pqr.hasSort(value) {
+			return fmt.Errorf("%s is duplicated sort key", value)
+		}
 
-			value := strings.ToLower(strings.TrimSpace(parts[0]))
-			direction := strings.ToLower(strings.TrimSpace(parts[1]))
+		pqr.sortList = append(pqr.sortList, Sort{Key: value, Direction: direction})
+	}
+
+	pqr.setOrderingKey()
+
+	return nil
+}
+
+// withDefaultSort is applied when no sort was requested.
+func (pqr *PaginationParams) withDefaultSort(sort Sort) {
+	if len(pqr.sortList) != 0 {
+		return
+	}
+
+	pqr.sortList = append(pqr.sortList, sort)
+	pqr.setOrderingKey()
+}
+
+// withTieBreaker makes sure the sorts end with a unique key, so rows sharing the
+// same sort values keep their order between requests and pages never shuffle.
+func (pqr *PaginationParams) withTieBreaker(key string) {
+	if pqr.hasSort(key) {
+		return
+	}
 
-			if direction != "asc" && direction != "desc" {
-				return errors.New("not valid sort direction")
-			}
+	pqr.sortList = append(pqr.sortList, Sort{Key: key, Direction: "asc"})
+	pqr.setOrderingKey()
+}
 
-			pqr.sortMap[value] = direction
-			orderingBuilder.WriteString(sortRaw)
+func (pqr *PaginationParams) hasSort(key string) bool {
+	for _, sort := range pqr.sortList {
+		if sort.Key == key {
+			return true
 		}
 	}
+	return false
+}
+
+// setOrderingKey derives the cache ordering key from the normalized sorts,
+// so equivalent requests share a key and it always matches the DB ordering.
+func (pqr *PaginationParams) setOrderingKey() {
+	sorts := make([]string, 0, len(pqr.sortList))
+	for _, sort := range pqr.sortList {
+		sorts = append(sorts, sort.Key+":"+sort.Direction)
+	}
+	ordering := strings.Join(sorts, ",")
+
+	if pqr.Cursor != "" {
+		pqr.orderingKey = fmt.Sprintf("%d:cursor:%s:%s", pqr.Limit, pqr.Cursor, ordering)
+	} else {
+		pqr.orderingKey = fmt.Sprintf("%d:%d:%s", pqr.Limit, pqr.Page, ordering)
+	}
+}
+
+// decodeCursor validates the cursor against the current sorts.
+func (pqr *PaginationParams) decodeCursor() error {
+	pqr.cursorValues = nil
+	if pqr.Cursor == "" {
+		return nil
+	}
 
-	pqr.orderingKey = fmt.Sprintf("%d:%d:%s", pqr.Limit, pqr.Page, orderingBuilder.String())
+	raw, err := base64.RawURLEncoding.DecodeString(pqr.Cursor)
+	if err != nil {
+		return errors.New("not valid cursor")
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return errors.New("not valid cursor")
+	}
 
+	if len(c.Keys) != len(c.Values) || !slices.Equal(c.Keys, cursorSorts(pqr.sortList)) {
+		return errors.New("cursor does not match the requested sort")
+	}
+
+	pqr.cursorValues = c.Values
 	return nil
 }
 
-func (pqr *PaginationParams) GetSortMapping() map[string]string {
-	return pqr.sortMap
+// GetSorts returns the sorts in the order they must be applied.
+func (pqr *PaginationParams) GetSorts() []Sort {
+	return pqr.sortList
+}
+
+// GetCursorValues returns the decoded cursor values, aligned with GetSorts.
+func (pqr *PaginationParams) GetCursorValues() []string {
+	return pqr.cursorValues
 }
 
 func (pqr *PaginationParams) GetOrderingKey() string {
 	return pqr.orderingKey
 }
+
+type cursor struct {
+	// Keys are the sorts as key:direction, the values are only valid for them
+	Keys   []string `json:"k"`
+	Values []string `json:"v"`
+}
+
+// EncodeCursor builds the opaque cursor pointing after the row holding values.
+func EncodeCursor(sorts []Sort, values []string) string {
+	raw, _ := json.Marshal(cursor{Keys: cursorSorts(sorts), Values: values})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// cursorSorts writes the sorts as key:direction, a cursor is rejected when
+// the direction of a key changed as well.
+func cursorSorts(sorts []Sort) []string {
+	keys := make([]string, 0, len(sorts))
+	for _, sort := range sorts {
+		keys = append(keys, sort.Key+":"+sort.Direction)
+	}
+	return keys
+}
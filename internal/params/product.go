@@ -3,31 +3,92 @@ package params
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/elangreza/lion-superindo/internal/domain"
 	errs "github.com/elangreza/lion-superindo/pkg/error"
 )
 
 type ProductResponse struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Price     int       `json:"price"`
-	Type      string    `json:"type"`
-	CreatedAt time.Time `json:"created_at"`
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	SKU     string `json:"sku,omitempty"`
+	Barcode string `json:"barcode,omitempty"`
+	// Price and FinalPrice are in the minor units of Currency
+	Price    int    `json:"price"`
+	Currency string `json:"currency"`
+	// Price after the best active promotion, which is returned in Promotion
+	FinalPrice int                       `json:"final_price"`
+	Promotion  *AppliedPromotionResponse `json:"promotion,omitempty"`
+	Type       string                    `json:"type"`
+	Variants   []VariantResponse         `json:"variants,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	DeletedAt  *time.Time                `json:"deleted_at,omitempty"`
+	// only returned in fuzzy search mode
+	Similarity *float64 `json:"similarity,omitempty"`
 }
 
 type ListProductsResponses struct {
-	TotalData int               `json:"total_data"`
-	TotalPage int               `json:"total_page"`
-	Products  []ProductResponse `json:"products"`
+	TotalData  int                     `json:"total_data"`
+	TotalPage  int                     `json:"total_page"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+	Facets     map[string][]FacetCount `json:"facets,omitempty"`
+	Products   []ProductResponse       `json:"products"`
 }
 
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+const (
+	// SearchModeFulltext matches whole words of the name and ranks the results.
+	SearchModeFulltext = "fulltext"
+	// SearchModeFuzzy matches names by trigram similarity, tolerating typos.
+	SearchModeFuzzy = "fuzzy"
+
+	// DefaultMinSimilarity is the pg_trgm default similarity threshold.
+	DefaultMinSimilarity = 0.3
+
+	// FacetType counts the products of every product type.
+	FacetType = "type"
+)
+
 type ListProductsQueryParams struct {
 	// can be searched by id or name
 	Search string
+	// how Search is matched. Empty means substring match on name or exact id
+	SearchMode string
+	// lowest trigram similarity accepted in fuzzy search mode, between 0 and 1
+	MinSimilarity float64
 	// can be filtered by product type
 	Types []string
+	// also matches the descendants of Types in the category tree
+	IncludeSubtypes bool
+	// includes soft deleted products when true
+	IncludeDeleted bool
+	// can be filtered by currency, which is required to filter or sort by price
+	Currency string
+	// can be filtered by price range, both ends are inclusive
+	MinPrice *int
+	MaxPrice *int
+	// can be filtered by creation date, CreatedAfter is inclusive and CreatedBefore is exclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// returns the prices as they were at this time, products created later are left out
+	AsOf *time.Time
+	// only returns the products with stock at this store
+	InStockAt int
+	// can be filtered by variant attributes as name:value, comma separated or repeated.
+	// Every attribute must be on the same variant
+	Variants []string
+	// parsed from Variants
+	VariantAttributes map[string]string
+	// facets to count alongside the products
+	Facets []string
 
 	// local var. used for caching key
 	paramsKey string
@@ -42,19 +103,148 @@ func (pqr *ListProductsQueryParams) Validate() error {
 		return errs.ValidationError{Message: err.Error()}
 	}
 
+	pqr.Search = strings.TrimSpace(pqr.Search)
+	pqr.SearchMode = strings.ToLower(strings.TrimSpace(pqr.SearchMode))
+	switch pqr.SearchMode {
+	case "":
+	case SearchModeFulltext, SearchModeFuzzy:
+		if pqr.Search == "" {
+			return errs.ValidationError{Message: fmt.Sprintf("search cannot be empty in %s search mode", pqr.SearchMode)}
+		}
+	default:
+		return errs.ValidationError{Message: fmt.Sprintf("%s not valid search mode", pqr.SearchMode)}
+	}
+
+	if pqr.MinSimilarity != 0 && pqr.SearchMode != SearchModeFuzzy {
+		return errs.ValidationError{Message: "min_similarity requires fuzzy search mode"}
+	}
+	if pqr.MinSimilarity < 0 || pqr.MinSimilarity > 1 {
+		return errs.ValidationError{Message: "min_similarity must be between 0 and 1"}
+	}
+	if pqr.SearchMode == SearchModeFuzzy && pqr.MinSimilarity == 0 {
+		pqr.MinSimilarity = DefaultMinSimilarity
+	}
+
 	validSortKeys := map[string]bool{
 		"id": true, "created_at": true, "price": true, "name": true,
 	}
-	for sortKey := range pqr.GetSortMapping() {
-		if !validSortKeys[sortKey] {
-			return errs.ValidationError{Message: fmt.Sprintf("%s not valid sort key", sortKey)}
+	if pqr.IsRanked() {
+		validSortKeys["relevance"] = true
+	}
+	for _, sort := range pqr.GetSorts() {
+		if !validSortKeys[sort.Key] {
+			if sort.Key == "relevance" {
+				return errs.ValidationError{Message: "relevance sort requires a ranked search mode"}
+			}
+			return errs.ValidationError{Message: fmt.Sprintf("%s not valid sort key", sort.Key)}
 		}
 	}
 
-	pqr.Search = strings.TrimSpace(pqr.Search)
+	if pqr.IsRanked() {
+		pqr.withDefaultSort(Sort{Key: "relevance", Direction: "desc"})
+	}
+
+	pqr.withTieBreaker("id")
+	if err := pqr.decodeCursor(); err != nil {
+		return errs.ValidationError{Message: err.Error()}
+	}
+
+	types := []string{}
+	for _, productType := range pqr.Types {
+		for _, t := range strings.Split(productType, ",") {
+			t = strings.ToLower(strings.TrimSpace(t))
+			if t != "" && !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	pqr.Types = types
+
+	if len(pqr.Variants) != 0 {
+		attributes := make(map[string]string)
+		for _, variant := range pqr.Variants {
+			for _, attribute := range strings.Split(variant, ",") {
+				name, value, ok := strings.Cut(attribute, ":")
+				if !ok {
+					return errs.ValidationError{Message: fmt.Sprintf("%s not valid variant, expected name:value", strings.TrimSpace(attribute))}
+				}
+				name = strings.ToLower(strings.TrimSpace(name))
+				if _, ok := attributes[name]; ok {
+					return errs.ValidationError{Message: fmt.Sprintf("variant %s is duplicated", name)}
+				}
+				attributes[name] = value
+			}
+		}
+
+		parsed, err := parseVariantAttributes(attributes)
+		if err != nil {
+			return err
+		}
+		pqr.VariantAttributes = parsed
+	}
+
+	if pqr.IncludeSubtypes && len(pqr.Types) == 0 {
+		return errs.ValidationError{Message: "include_subtypes requires type"}
+	}
+
+	facets := []string{}
+	for _, facet := range pqr.Facets {
+		for _, f := range strings.Split(facet, ",") {
+			f = strings.ToLower(strings.TrimSpace(f))
+			if f != FacetType {
+				return errs.ValidationError{Message: fmt.Sprintf("%s not valid facet", f)}
+			}
+			if !slices.Contains(facets, f) {
+				facets = append(facets, f)
+			}
+		}
+	}
+	pqr.Facets = facets
+
+	if pqr.Currency != "" {
+		currency, err := parseCurrency(pqr.Currency)
+		if err != nil {
+			return err
+		}
+		pqr.Currency = currency
+	}
+	// amounts of different currencies cannot be compared, so prices are
+	// filtered and sorted within the catalog currency unless one is given
+	if pqr.Currency == "" && (pqr.MinPrice != nil || pqr.MaxPrice != nil || pqr.hasSort("price")) {
+		pqr.Currency = domain.DefaultCurrency
+	}
+
+	if pqr.MinPrice != nil && *pqr.MinPrice < 0 {
+		return errs.ValidationError{Message: "min_price cannot be negative"}
+	}
+	if pqr.MaxPrice != nil && *pqr.MaxPrice < 0 {
+		return errs.ValidationError{Message: "max_price cannot be negative"}
+	}
+	if pqr.MinPrice != nil && pqr.MaxPrice != nil && *pqr.MinPrice > *pqr.MaxPrice {
+		return errs.ValidationError{Message: "min_price cannot be greater than max_price"}
+	}
+	if pqr.CreatedAfter != nil && pqr.CreatedBefore != nil && !pqr.CreatedAfter.Before(*pqr.CreatedBefore) {
+		return errs.ValidationError{Message: "created_after must be before created_before"}
+	}
+	if pqr.AsOf != nil && pqr.AsOf.After(time.Now()) {
+		return errs.ValidationError{Message: "as_of cannot be in the future"}
+	}
+
 	mapKey := map[string]any{
-		"search": pqr.Search,
-		"types":  pqr.Types,
+		"search":           pqr.Search,
+		"search_mode":      pqr.SearchMode,
+		"min_similarity":   pqr.MinSimilarity,
+		"types":            pqr.Types,
+		"include_subtypes": pqr.IncludeSubtypes,
+		"include_deleted":  pqr.IncludeDeleted,
+		"currency":         pqr.Currency,
+		"min_price":        pqr.MinPrice,
+		"max_price":        pqr.MaxPrice,
+		"created_after":    pqr.CreatedAfter,
+		"created_before":   pqr.CreatedBefore,
+		"as_of":            pqr.AsOf,
+		"in_stock_at":      pqr.InStockAt,
+		"variant":          pqr.VariantAttributes,
 	}
 
 	key, err := json.Marshal(mapKey)
@@ -67,14 +257,30 @@ func (pqr *ListProductsQueryParams) Validate() error {
 	return nil
 }
 
+func (pqr *ListProductsQueryParams) HasFacet(facet string) bool {
+	return slices.Contains(pqr.Facets, facet)
+}
+
+// IsRanked reports whether the search mode produces a relevance score.
+func (pqr *ListProductsQueryParams) IsRanked() bool {
+	return pqr.SearchMode == SearchModeFulltext || pqr.SearchMode == SearchModeFuzzy
+}
+
 func (pqr *ListProductsQueryParams) GetParamsKey() string {
 	return pqr.paramsKey
 }
 
 type CreateProductRequest struct {
-	Name  string `json:"name"`
+	Name string `json:"name"`
+	// Price is in the minor units of Currency, e.g. 1050 USD is 10.50 US dollars
 	Price int    `json:"price"`
 	Type  string `json:"type"`
+	// ISO 4217 code, DefaultCurrency when empty
+	Currency string `json:"currency,omitempty"`
+	// SKU is optional and unique, it is stored uppercase
+	SKU string `json:"sku,omitempty"`
+	// Barcode is optional and unique, an EAN-13 or a UPC-A stored as EAN-13
+	Barcode string `json:"barcode,omitempty"`
 }
 
 type CreateProductResponse struct {
@@ -82,6 +288,15 @@ type CreateProductResponse struct {
 }
 
 func (pqr *CreateProductRequest) Validate() error {
+	if pqr.Currency == "" {
+		pqr.Currency = domain.DefaultCurrency
+	}
+
+	return pqr.validate()
+}
+
+// validate leaves an empty Currency empty.
+func (pqr *CreateProductRequest) validate() error {
 	if len(pqr.Name) == 0 {
 		return errs.ValidationError{Message: "name cannot be empty"}
 	}
@@ -92,5 +307,220 @@ func (pqr *CreateProductRequest) Validate() error {
 	if pqr.Price < 0 {
 		return errs.ValidationError{Message: "price cannot be negative"}
 	}
+	if pqr.Currency != "" {
+		currency, err := parseCurrency(pqr.Currency)
+		if err != nil {
+			return err
+		}
+		pqr.Currency = currency
+	}
+
+	sku, err := parseSKU(pqr.SKU)
+	if err != nil {
+		return err
+	}
+	pqr.SKU = sku
+
+	pqr.Barcode = strings.TrimSpace(pqr.Barcode)
+	if pqr.Barcode != "" {
+		barcode, err := ParseBarcode(pqr.Barcode)
+		if err != nil {
+			return err
+		}
+		pqr.Barcode = barcode
+	}
+
 	return nil
 }
+
+var skuPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9._-]{0,63}$`)
+
+// parseSKU returns the uppercase sku, an empty sku is left empty.
+func parseSKU(sku string) (string, error) {
+	sku = strings.ToUpper(strings.TrimSpace(sku))
+	if sku != "" && !skuPattern.MatchString(sku) {
+		return "", errs.ValidationError{Message: "sku must be up to 64 letters, digits, '-', '_' or '.'"}
+	}
+	return sku, nil
+}
+
+// ParseBarcode checks the length and check digit of an EAN-13 or UPC-A code.
+// A UPC-A is returned as its EAN-13 form, with a leading zero, so both
+// spellings of the same code are stored and looked up the same way.
+func ParseBarcode(code string) (string, error) {
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return "", errs.ValidationError{Message: fmt.Sprintf("%s not valid barcode, it can only have digits", code)}
+		}
+	}
+
+	switch len(code) {
+	case 12:
+		code = "0" + code
+	case 13:
+	default:
+		return "", errs.ValidationError{Message: fmt.Sprintf("%s not valid barcode, expected 13 digits EAN-13 or 12 digits UPC-A", code)}
+	}
+
+	// the digits are weighted 1 and 3 alternately from the left
+	sum := 0
+	for i, c := range code[:12] {
+		digit := int(c - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	if checkDigit := (10 - sum%10) % 10; int(code[12]-'0') != checkDigit {
+		return "", errs.ValidationError{Message: fmt.Sprintf("%s not valid barcode, check digit should be %d", code, checkDigit)}
+	}
+
+	return code, nil
+}
+
+// parseCurrency returns the uppercase ISO 4217 code.
+func parseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !domain.IsCurrency(code) {
+		return "", errs.ValidationError{Message: fmt.Sprintf("%s not valid currency", code)}
+	}
+	return code, nil
+}
+
+// MaxBulkProducts limits the products of a single bulk request.
+const MaxBulkProducts = 1000
+
+type BulkCreateProductsRequest struct {
+	Products []CreateProductRequest
+	// Partial creates the valid products even when others are rejected.
+	// By default nothing is created when any product is rejected.
+	Partial bool
+}
+
+// Validate only checks the batch, every product is validated on its own so
+// the errors can be reported per item.
+func (pqr *BulkCreateProductsRequest) Validate() error {
+	if len(pqr.Products) == 0 {
+		return errs.ValidationError{Message: "products cannot be empty"}
+	}
+	if len(pqr.Products) > MaxBulkProducts {
+		return errs.ValidationError{Message: fmt.Sprintf("cannot create more than %d products at once", MaxBulkProducts)}
+	}
+	return nil
+}
+
+type BulkCreateProductResult struct {
+	// Index is the position of the product in the request
+	Index int    `json:"index"`
+	ID    int    `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type BulkCreateProductsResponse struct {
+	Created int                       `json:"created"`
+	Failed  int                       `json:"failed"`
+	Results []BulkCreateProductResult `json:"results"`
+}
+
+// UpdateProductRequest is a full replacement of the product's mutable fields,
+// except an empty Currency which keeps the stored currency, so the price of a USD
+// product is not relabeled as IDR when the field is left out.
+type UpdateProductRequest CreateProductRequest
+
+func (pqr *UpdateProductRequest) Validate() error {
+	return (*CreateProductRequest)(pqr).validate()
+}
+
+// PatchProductRequest follows JSON merge patch (RFC 7396) semantics.
+// Omitted fields are left untouched. An explicit null is rejected instead of
+// removing the value, the optional sku and barcode are removed with "".
+type PatchProductRequest struct {
+	Name     *string `json:"name,omitempty"`
+	Price    *int    `json:"price,omitempty"`
+	Type     *string `json:"type,omitempty"`
+	Currency *string `json:"currency,omitempty"`
+	SKU      *string `json:"sku,omitempty"`
+	Barcode  *string `json:"barcode,omitempty"`
+}
+
+func (pqr *PatchProductRequest) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		if string(value) == "null" {
+			return errs.ValidationError{Message: fmt.Sprintf("%s cannot be null", key)}
+		}
+	}
+
+	type patch PatchProductRequest
+	return json.Unmarshal(data, (*patch)(pqr))
+}
+
+// Apply merges the patch into the current state of the product.
+func (pqr PatchProductRequest) Apply(product domain.Product) UpdateProductRequest {
+	req := UpdateProductRequest{
+		Name:     product.Name,
+		Price:    product.Price.Amount,
+		Type:     product.ProductType.Name,
+		Currency: product.Price.Currency,
+		SKU:      product.SKU,
+		Barcode:  product.Barcode,
+	}
+
+	if pqr.Name != nil {
+		req.Name = *pqr.Name
+	}
+	if pqr.Price != nil {
+		req.Price = *pqr.Price
+	}
+	if pqr.Type != nil {
+		req.Type = *pqr.Type
+	}
+	if pqr.Currency != nil {
+		req.Currency = *pqr.Currency
+	}
+	if pqr.SKU != nil {
+		req.SKU = *pqr.SKU
+	}
+	if pqr.Barcode != nil {
+		req.Barcode = *pqr.Barcode
+	}
+
+	return req
+}
+
+type SuggestProductsQueryParams struct {
+	// prefix typed in the search box
+	Query string
+	Limit int
+}
+
+func (pqr *SuggestProductsQueryParams) Validate() error {
+	pqr.Query = strings.ToLower(strings.TrimSpace(pqr.Query))
+	if len(pqr.Query) == 0 {
+		return errs.ValidationError{Message: "q cannot be empty"}
+	}
+
+	if pqr.Limit < 1 {
+		pqr.Limit = 5
+	}
+
+	if pqr.Limit > 20 {
+		return errs.ValidationError{Message: "limit cannot be greater than 20"}
+	}
+
+	return nil
+}
+
+// GetCacheKey identifies the suggestions of this prefix and limit.
+func (pqr *SuggestProductsQueryParams) GetCacheKey() string {
+	return fmt.Sprintf("%d:%s", pqr.Limit, pqr.Query)
+}
+
+type SuggestProductsResponse struct {
+	Products []string `json:"products"`
+	Types    []string `json:"types"`
+}
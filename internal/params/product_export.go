@@ -0,0 +1,53 @@
+package params
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+)
+
+// ExportProductsHeader is the first row of the CSV and XLSX exports.
+var ExportProductsHeader = []string{"id", "name", "sku", "barcode", "price", "final_price", "currency", "type", "created_at", "deleted_at"}
+
+// ExportRecord returns the product as a row aligned with ExportProductsHeader.
+func (pr ProductResponse) ExportRecord() []string {
+	var deletedAt string
+	if pr.DeletedAt != nil {
+		deletedAt = pr.DeletedAt.Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.Itoa(pr.ID),
+		pr.Name,
+		pr.SKU,
+		pr.Barcode,
+		strconv.Itoa(pr.Price),
+		strconv.Itoa(pr.FinalPrice),
+		pr.Currency,
+		pr.Type,
+		pr.CreatedAt.Format(time.RFC3339),
+		deletedAt,
+	}
+}
+
+// MarshalCSV returns the product with the ExportProductsHeader.
+func (pr ProductResponse) MarshalCSV() [][]string {
+	return [][]string{ExportProductsHeader, pr.ExportRecord()}
+}
+
+// MarshalCSV returns the products of the page with the ExportProductsHeader,
+// the pagination and the facets are only sent in JSON and MessagePack.
+func (lpr ListProductsResponses) MarshalCSV() [][]string {
+	records := make([][]string, 0, len(lpr.Products)+1)
+	records = append(records, ExportProductsHeader)
+	for _, product := range lpr.Products {
+		records = append(records, product.ExportRecord())
+	}
+
+	return records
+}
@@ -0,0 +1,44 @@
+package params
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// ImportProductsHeader is the optional first row of an import file, columns are always in this order.
+// The currency column can be left out, the prices are then in the default currency.
+var ImportProductsHeader = []string{"name", "price", "type", "currency"}
+
+type ImportProductsResponse struct {
+	// Created and Updated count the imported rows, an existing product with the same name is updated
+	Created  int                 `json:"created"`
+	Updated  int                 `json:"updated"`
+	Rejected []ImportRejectedRow `json:"rejected"`
+}
+
+type ImportRejectedRow struct {
+	// Line is the line number of the row in the import file, starting at 1
+	Line   int      `json:"line"`
+	Record []string `json:"record"`
+	Error  string   `json:"error"`
+}
+
+// WriteReport writes the rejected rows as CSV, so they can be fixed and imported again.
+func (pir *ImportProductsResponse) WriteReport(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"line"}, append(ImportProductsHeader, "error")...)); err != nil {
+		return err
+	}
+
+	for _, row := range pir.Rejected {
+		record := make([]string, len(ImportProductsHeader))
+		copy(record, row.Record)
+		if err := writer.Write(append([]string{strconv.Itoa(row.Line)}, append(record, row.Error)...)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,57 @@
+package params
+
+import (
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type ProductPricesResponse struct {
+	ProductID int `json:"product_id"`
+	// Prices are ordered from the latest one
+	Prices []ProductPriceResponse `json:"prices"`
+}
+
+type ProductPriceResponse struct {
+	Price         int       `json:"price"`
+	Currency      string    `json:"currency"`
+	EffectiveFrom time.Time `json:"effective_from"`
+	// EffectiveTo is when the next price took over, empty for the current price
+	EffectiveTo *time.Time `json:"effective_to,omitempty"`
+}
+
+type ScheduleProductPriceRequest struct {
+	Price       int       `json:"price"`
+	EffectiveAt time.Time `json:"effective_at"`
+	// ISO 4217 code, the current currency of the product when empty
+	Currency string `json:"currency,omitempty"`
+}
+
+func (spr *ScheduleProductPriceRequest) Validate() error {
+	if spr.Price < 0 {
+		return errs.ValidationError{Message: "price cannot be negative"}
+	}
+	if spr.EffectiveAt.IsZero() {
+		return errs.ValidationError{Message: "effective_at cannot be empty"}
+	}
+	if !spr.EffectiveAt.After(time.Now()) {
+		return errs.ValidationError{Message: "effective_at must be in the future"}
+	}
+	if spr.Currency != "" {
+		currency, err := parseCurrency(spr.Currency)
+		if err != nil {
+			return err
+		}
+		spr.Currency = currency
+	}
+	return nil
+}
+
+type ScheduledPriceResponse struct {
+	ID          int       `json:"id"`
+	ProductID   int       `json:"product_id"`
+	Price       int       `json:"price"`
+	Currency    string    `json:"currency"`
+	EffectiveAt time.Time `json:"effective_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package params
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type ProductTypeResponse struct {
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"`
+	// products of this type only
+	TotalProducts int `json:"total_products"`
+	// products of this type and all of its descendants
+	SubtreeProducts int       `json:"subtree_products"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type CreateProductTypeRequest struct {
+	Name string `json:"name"`
+	// parent category, empty for a root category
+	Parent string `json:"parent,omitempty"`
+}
+
+func (pqr *CreateProductTypeRequest) Validate() error {
+	// types are stored lowercase, see CreateProductRequest.Validate
+	pqr.Name = strings.ToLower(strings.TrimSpace(pqr.Name))
+	if len(pqr.Name) == 0 {
+		return errs.ValidationError{Message: "name cannot be empty"}
+	}
+
+	pqr.Parent = strings.ToLower(strings.TrimSpace(pqr.Parent))
+	if pqr.Parent == pqr.Name {
+		return errs.ValidationError{Message: "product type cannot be its own parent"}
+	}
+	return nil
+}
+
+// RenameProductTypeRequest renames a product type, the products follow the new name.
+type RenameProductTypeRequest struct {
+	Name string `json:"name"`
+}
+
+func (pqr *RenameProductTypeRequest) Validate() error {
+	pqr.Name = strings.ToLower(strings.TrimSpace(pqr.Name))
+	if len(pqr.Name) == 0 {
+		return errs.ValidationError{Message: "name cannot be empty"}
+	}
+	return nil
+}
+
+// MoveProductTypeRequest moves a product type with all of its descendants under another parent.
+type MoveProductTypeRequest struct {
+	// new parent category, empty moves the type to the root
+	Parent string `json:"parent"`
+}
+
+func (pqr *MoveProductTypeRequest) Validate() error {
+	pqr.Parent = strings.ToLower(strings.TrimSpace(pqr.Parent))
+	return nil
+}
@@ -0,0 +1,107 @@
+package params
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// MaxVariantAttributes limits the options of a single variant.
+const MaxVariantAttributes = 10
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+type CreateVariantRequest struct {
+	// Attributes maps the option names to their values, e.g. {"flavor": "chocolate"}.
+	// Both are stored lowercase
+	Attributes map[string]string `json:"attributes"`
+	// Price is in the minor units of Currency
+	Price int `json:"price"`
+	// ISO 4217 code, the currency of the product when empty
+	Currency string `json:"currency,omitempty"`
+	// SKU is optional and unique across products and variants, it is stored uppercase
+	SKU string `json:"sku,omitempty"`
+}
+
+func (vr *CreateVariantRequest) Validate() error {
+	attributes, err := parseVariantAttributes(vr.Attributes)
+	if err != nil {
+		return err
+	}
+	vr.Attributes = attributes
+
+	if vr.Price < 0 {
+		return errs.ValidationError{Message: "price cannot be negative"}
+	}
+
+	if vr.Currency != "" {
+		currency, err := parseCurrency(vr.Currency)
+		if err != nil {
+			return err
+		}
+		vr.Currency = currency
+	}
+
+	sku, err := parseSKU(vr.SKU)
+	if err != nil {
+		return err
+	}
+	vr.SKU = sku
+
+	return nil
+}
+
+// UpdateVariantRequest is a full replacement of the variant.
+type UpdateVariantRequest CreateVariantRequest
+
+func (vr *UpdateVariantRequest) Validate() error {
+	return (*CreateVariantRequest)(vr).Validate()
+}
+
+// parseVariantAttributes returns the lowercase attributes, names differing only
+// by case are rejected since they would be the same option.
+func parseVariantAttributes(attributes map[string]string) (map[string]string, error) {
+	if len(attributes) == 0 {
+		return nil, errs.ValidationError{Message: "attributes cannot be empty"}
+	}
+	if len(attributes) > MaxVariantAttributes {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("cannot have more than %d attributes", MaxVariantAttributes)}
+	}
+
+	parsed := make(map[string]string, len(attributes))
+	for name, value := range attributes {
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !attributeNamePattern.MatchString(name) {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("%s not valid attribute name, expected up to 32 letters, digits or '_'", name)}
+		}
+		if value == "" {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("attribute %s cannot be empty", name)}
+		}
+		if len(value) > 64 {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("attribute %s cannot be longer than 64 characters", name)}
+		}
+		if _, ok := parsed[name]; ok {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("attribute %s is duplicated", name)}
+		}
+		parsed[name] = value
+	}
+
+	return parsed, nil
+}
+
+type VariantResponse struct {
+	ID         int               `json:"id"`
+	SKU        string            `json:"sku,omitempty"`
+	Attributes map[string]string `json:"attributes"`
+	// Price and FinalPrice are in the minor units of Currency
+	Price    int    `json:"price"`
+	Currency string `json:"currency"`
+	// Price after the best active promotion of the product, which is returned in Promotion
+	FinalPrice int                       `json:"final_price"`
+	Promotion  *AppliedPromotionResponse `json:"promotion,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+}
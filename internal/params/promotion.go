@@ -0,0 +1,106 @@
+package params
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+const (
+	// DiscountPercentage takes DiscountValue percent off the price.
+	DiscountPercentage = "percentage"
+	// DiscountFixed takes DiscountValue off the price.
+	DiscountFixed = "fixed"
+)
+
+type CreatePromotionRequest struct {
+	Name          string `json:"name"`
+	DiscountType  string `json:"discount_type"`
+	DiscountValue int    `json:"discount_value"`
+	// ISO 4217 code of a fixed DiscountValue, in minor units. A fixed discount
+	// only applies to the prices of its currency
+	Currency string `json:"currency,omitempty"`
+	// the promotion covers this product only
+	ProductID int `json:"product_id,omitempty"`
+	// the promotion covers the products of this type and of its descendants.
+	// Without ProductID and ProductType it covers the whole catalog
+	ProductType string `json:"product_type,omitempty"`
+	// the promotion is active from StartsAt (inclusive) until EndsAt (exclusive)
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+func (cpr *CreatePromotionRequest) Validate() error {
+	cpr.Name = strings.TrimSpace(cpr.Name)
+	if len(cpr.Name) == 0 {
+		return errs.ValidationError{Message: "name cannot be empty"}
+	}
+
+	cpr.DiscountType = strings.ToLower(strings.TrimSpace(cpr.DiscountType))
+	switch cpr.DiscountType {
+	case DiscountPercentage:
+		if cpr.DiscountValue < 1 || cpr.DiscountValue > 100 {
+			return errs.ValidationError{Message: "percentage discount_value must be between 1 and 100"}
+		}
+	case DiscountFixed:
+		if cpr.DiscountValue < 1 {
+			return errs.ValidationError{Message: "fixed discount_value must be positive"}
+		}
+	default:
+		return errs.ValidationError{Message: "discount_type must be percentage or fixed"}
+	}
+
+	switch {
+	case cpr.DiscountType == DiscountFixed && cpr.Currency == "":
+		return errs.ValidationError{Message: "currency cannot be empty for a fixed discount"}
+	case cpr.DiscountType == DiscountFixed:
+		currency, err := parseCurrency(cpr.Currency)
+		if err != nil {
+			return err
+		}
+		cpr.Currency = currency
+	case cpr.Currency != "":
+		return errs.ValidationError{Message: "currency can only be used with a fixed discount"}
+	}
+
+	if cpr.ProductID < 0 {
+		return errs.ValidationError{Message: "not valid product_id"}
+	}
+	// types are stored lowercase, see CreateProductRequest.Validate
+	cpr.ProductType = strings.ToLower(strings.TrimSpace(cpr.ProductType))
+	if cpr.ProductID != 0 && cpr.ProductType != "" {
+		return errs.ValidationError{Message: "product_id and product_type cannot be used together"}
+	}
+
+	if cpr.StartsAt.IsZero() || cpr.EndsAt.IsZero() {
+		return errs.ValidationError{Message: "starts_at and ends_at cannot be empty"}
+	}
+	if !cpr.StartsAt.Before(cpr.EndsAt) {
+		return errs.ValidationError{Message: "starts_at must be before ends_at"}
+	}
+	return nil
+}
+
+type PromotionResponse struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	DiscountType  string    `json:"discount_type"`
+	DiscountValue int       `json:"discount_value"`
+	Currency      string    `json:"currency,omitempty"`
+	ProductID     int       `json:"product_id,omitempty"`
+	ProductType   string    `json:"product_type,omitempty"`
+	StartsAt      time.Time `json:"starts_at"`
+	EndsAt        time.Time `json:"ends_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AppliedPromotionResponse is the promotion giving a product its final price.
+type AppliedPromotionResponse struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	DiscountType  string    `json:"discount_type"`
+	DiscountValue int       `json:"discount_value"`
+	Currency      string    `json:"currency,omitempty"`
+	EndsAt        time.Time `json:"ends_at"`
+}
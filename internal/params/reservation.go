@@ -0,0 +1,59 @@
+package params
+
+import (
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+const (
+	// ReservationPending holds the stock until it is confirmed, cancelled or expired.
+	ReservationPending = "pending"
+	// ReservationConfirmed keeps the stock out of the inventory for good.
+	ReservationConfirmed = "confirmed"
+	// ReservationCancelled and ReservationExpired put the stock back.
+	ReservationCancelled = "cancelled"
+	ReservationExpired   = "expired"
+
+	DefaultReservationTTL = 15 * 60
+	MaxReservationTTL     = 60 * 60
+)
+
+type CreateReservationRequest struct {
+	StoreID   int `json:"store_id"`
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+	// seconds the stock is held for, DefaultReservationTTL when empty
+	TTL int `json:"ttl_seconds,omitempty"`
+}
+
+func (crr *CreateReservationRequest) Validate() error {
+	if crr.StoreID < 1 {
+		return errs.ValidationError{Message: "not valid store_id"}
+	}
+	if crr.ProductID < 1 {
+		return errs.ValidationError{Message: "not valid product_id"}
+	}
+	if crr.Quantity < 1 {
+		return errs.ValidationError{Message: "quantity must be positive"}
+	}
+
+	if crr.TTL == 0 {
+		crr.TTL = DefaultReservationTTL
+	}
+	if crr.TTL < 0 || crr.TTL > MaxReservationTTL {
+		return errs.ValidationError{Message: "ttl_seconds must be between 1 and 3600"}
+	}
+	return nil
+}
+
+type ReservationResponse struct {
+	ID        int       `json:"id"`
+	StoreID   int       `json:"store_id"`
+	ProductID int       `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package params
+
+import (
+	"strings"
+	"time"
+
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type CreateStoreRequest struct {
+	Name string `json:"name"`
+}
+
+func (csr *CreateStoreRequest) Validate() error {
+	csr.Name = strings.TrimSpace(csr.Name)
+	if len(csr.Name) == 0 {
+		return errs.ValidationError{Message: "name cannot be empty"}
+	}
+	return nil
+}
+
+type StoreResponse struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AdjustStockRequest adds Delta to the stock of a product at a store, a
+// negative Delta takes it out. The stock never goes below zero.
+type AdjustStockRequest struct {
+	ProductID int `json:"product_id"`
+	Delta     int `json:"delta"`
+}
+
+func (asr *AdjustStockRequest) Validate() error {
+	if asr.ProductID < 1 {
+		return errs.ValidationError{Message: "not valid product_id"}
+	}
+	if asr.Delta == 0 {
+		return errs.ValidationError{Message: "delta cannot be zero"}
+	}
+	return nil
+}
+
+type StockLevelResponse struct {
+	StoreID     int       `json:"store_id"`
+	ProductID   int       `json:"product_id"`
+	ProductName string    `json:"product_name,omitempty"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
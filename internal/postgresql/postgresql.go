@@ -3,7 +3,10 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+
+	"github.com/lib/pq"
 )
 
 type PostgresRepo struct {
@@ -30,3 +33,10 @@ func runInTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
 
 	return tx.Commit()
 }
+
+// isUniqueViolation reports a write losing the race against a concurrent one
+// after both passed the uniqueness check.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
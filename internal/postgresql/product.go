@@ -9,13 +9,42 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/elangreza/lion-superindo/internal/domain"
 	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/lib/pq"
 )
 
+// qSubtypes selects the given product types with all of their descendants.
+const qSubtypes = `WITH RECURSIVE subtypes AS (
+	SELECT "name" FROM product_types WHERE "name" = ANY(?)
+	UNION
+	SELECT pt."name" FROM product_types pt JOIN subtypes s ON pt.parent_name = s."name"
+) SELECT "name" FROM subtypes`
+
+// qPriceAsOf selects the price of the product p at the given time.
+const qPriceAsOf = `SELECT h.price, h.currency FROM product_price_history h
+	WHERE h.product_id = p.id AND h.effective_from <= ?
+	ORDER BY h.effective_from DESC, h.id DESC LIMIT 1`
+
+// qInStockAt matches the products p with stock at the given store.
+const qInStockAt = `EXISTS (SELECT 1 FROM store_inventory si
+	WHERE si.store_id = ? AND si.product_id = p.id AND si.quantity > 0)`
+
 func (pr *PostgresRepo) listQuery(req params.ListProductsQueryParams) squirrel.SelectBuilder {
 	q := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select().From("products p")
 
 	search := strings.TrimSpace(req.Search)
-	if len(search) != 0 {
+	switch {
+	case req.SearchMode == params.SearchModeFulltext:
+		q = q.JoinClause("CROSS JOIN websearch_to_tsquery('simple', ?) AS query", search).
+			Where("p.search_vector @@ query")
+	case req.SearchMode == params.SearchModeFuzzy:
+		q = q.JoinClause("CROSS JOIN LOWER(?) AS term", search)
+		// the % operator is what lets the trigram index prefilter the rows, but it
+		// always uses the server threshold (0.3), so it is skipped for lower ones.
+		if req.MinSimilarity >= params.DefaultMinSimilarity {
+			q = q.Where("LOWER(p.name) % term")
+		}
+		q = q.Where("similarity(LOWER(p.name), term) >= ?", req.MinSimilarity)
+	case len(search) != 0:
 		if _, err := strconv.Atoi(search); err == nil {
 			q = q.Where(squirrel.Eq{"p.id": search})
 		} else {
@@ -23,22 +52,157 @@ func (pr *PostgresRepo) listQuery(req params.ListProductsQueryParams) squirrel.S
 		}
 	}
 
-	if len(req.Types) != 0 {
+	// products without a price at that time did not exist yet
+	if req.AsOf != nil {
+		q = q.JoinClause("JOIN LATERAL ("+qPriceAsOf+") AS ph ON TRUE", *req.AsOf)
+	}
+
+	if len(req.Types) != 0 && req.IncludeSubtypes {
+		q = q.Where(squirrel.Expr("p.product_type_name IN ("+qSubtypes+")", pq.Array(req.Types)))
+	} else if len(req.Types) != 0 {
 		q = q.Where(squirrel.Eq{"p.product_type_name": req.Types})
 	}
 
+	if req.Currency != "" {
+		q = q.Where(squirrel.Eq{currencyColumn(req): req.Currency})
+	}
+
+	if req.MinPrice != nil {
+		q = q.Where(squirrel.GtOrEq{priceColumn(req): *req.MinPrice})
+	}
+
+	if req.MaxPrice != nil {
+		q = q.Where(squirrel.LtOrEq{priceColumn(req): *req.MaxPrice})
+	}
+
+	if req.CreatedAfter != nil {
+		q = q.Where(squirrel.GtOrEq{"p.created_at": *req.CreatedAfter})
+	}
+
+	if req.CreatedBefore != nil {
+		q = q.Where(squirrel.Lt{"p.created_at": *req.CreatedBefore})
+	}
+
+	if req.InStockAt != 0 {
+		q = q.Where(qInStockAt, req.InStockAt)
+	}
+
+	if len(req.VariantAttributes) != 0 {
+		q = q.Where(qHasVariant, jsonAttributes(req.VariantAttributes))
+	}
+
+	if !req.IncludeDeleted {
+		q = q.Where("p.deleted_at IS NULL")
+	}
+
 	return q
 }
 
-func (pr *PostgresRepo) ListProducts(ctx context.Context, req params.ListProductsQueryParams) ([]domain.Product, error) {
-	q := pr.listQuery(req).Columns("id", "name", "price", "product_type_name", "created_at")
+func sortColumn(req params.ListProductsQueryParams, key string) string {
+	if key == "relevance" {
+		// query and term are joined by listQuery in the ranked search modes
+		switch req.SearchMode {
+		case params.SearchModeFulltext:
+			return "ts_rank(p.search_vector, query)"
+		case params.SearchModeFuzzy:
+			return "similarity(LOWER(p.name), term)"
+		}
+	}
+
+	if key == "price" {
+		return priceColumn(req)
+	}
+
+	return "p." + key
+}
+
+func priceColumn(req params.ListProductsQueryParams) string {
+	if req.AsOf != nil {
+		// ph is joined by listQuery when listing the prices at a past time
+		return "ph.price"
+	}
+
+	return "p.price"
+}
+
+func currencyColumn(req params.ListProductsQueryParams) string {
+	if req.AsOf != nil {
+		return "ph.currency"
+	}
 
-	if req.GetSortMapping() != nil {
-		for key, direction := range req.GetSortMapping() {
-			q = q.OrderBy(key + " " + direction)
+	return "p.currency"
+}
+
+// keysetPredicate selects the rows placed after the cursor for the given sorts.
+// For sorts (a, b) it expands to (a > x) OR (a = x AND b > y), flipping the
+// comparison for descending sorts, so mixed directions are supported.
+func keysetPredicate(req params.ListProductsQueryParams) squirrel.Sqlizer {
+	sorts, values := req.GetSorts(), req.GetCursorValues()
+	predicate := squirrel.Or{}
+	for i, sort := range sorts {
+		and := squirrel.And{}
+		for j := 0; j < i; j++ {
+			and = append(and, squirrel.Expr(sortColumn(req, sorts[j].Key)+" = ?", values[j]))
 		}
-	} else {
-		q = q.OrderBy("id asc")
+
+		operator := " > ?"
+		if sort.Direction == "desc" {
+			operator = " < ?"
+		}
+		and = append(and, squirrel.Expr(sortColumn(req, sort.Key)+operator, values[i]))
+
+		predicate = append(predicate, and)
+	}
+
+	return predicate
+}
+
+// productsQuery selects the products matching req in the requested order, without pagination.
+func (pr *PostgresRepo) productsQuery(req params.ListProductsQueryParams) squirrel.SelectBuilder {
+	price, currency := "price", "currency"
+	if req.AsOf != nil {
+		price, currency = priceColumn(req), currencyColumn(req)
+	}
+
+	q := pr.listQuery(req).Columns("id", "name", "COALESCE(sku, '')", "COALESCE(barcode, '')",
+		price, currency, "product_type_name", "created_at", "deleted_at")
+	if req.IsRanked() {
+		q = q.Column(sortColumn(req, "relevance") + " AS relevance")
+	}
+
+	for _, sort := range req.GetSorts() {
+		q = q.OrderBy(sortColumn(req, sort.Key) + " " + sort.Direction)
+	}
+
+	return q
+}
+
+func scanProduct(rows *sql.Rows, req params.ListProductsQueryParams) (domain.Product, error) {
+	var product domain.Product
+	dest := []any{
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.Barcode,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.ProductType.Name,
+		&product.CreatedAt,
+		&product.DeletedAt,
+	}
+	if req.IsRanked() {
+		dest = append(dest, &product.Relevance)
+	}
+
+	err := rows.Scan(dest...)
+	return product, err
+}
+
+func (pr *PostgresRepo) ListProducts(ctx context.Context, req params.ListProductsQueryParams) ([]domain.Product, error) {
+	q := pr.productsQuery(req)
+
+	if req.Cursor != "" {
+		q = q.Where(keysetPredicate(req))
 	}
 
 	q = q.Limit(uint64(req.Limit))
@@ -59,14 +223,7 @@ func (pr *PostgresRepo) ListProducts(ctx context.Context, req params.ListProduct
 
 	var products []domain.Product
 	for rows.Next() {
-		var product domain.Product
-		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Price,
-			&product.ProductType.Name,
-			&product.CreatedAt,
-		)
+		product, err := scanProduct(rows, req)
 		if err != nil {
 			return nil, err
 		}
@@ -77,9 +234,67 @@ func (pr *PostgresRepo) ListProducts(ctx context.Context, req params.ListProduct
 		return nil, err
 	}
 
+	if err = pr.withVariants(ctx, products); err != nil {
+		return nil, err
+	}
+
 	return products, nil
 }
 
+func (pr *PostgresRepo) GetProduct(ctx context.Context, id int) (*domain.Product, error) {
+	q := `SELECT id, "name", COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at
+		FROM products WHERE id = $1 AND deleted_at IS NULL;`
+
+	var product domain.Product
+	err := pr.db.QueryRowContext(ctx, q, id).Scan(
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.Barcode,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.ProductType.Name,
+		&product.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.withProductVariants(ctx, product)
+}
+
+func (pr *PostgresRepo) GetProductByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	q := `SELECT id, "name", COALESCE(sku, ''), barcode, price, currency, product_type_name, created_at
+		FROM products WHERE barcode = $1 AND deleted_at IS NULL;`
+
+	var product domain.Product
+	err := pr.db.QueryRowContext(ctx, q, barcode).Scan(
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.Barcode,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.ProductType.Name,
+		&product.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.withProductVariants(ctx, product)
+}
+
+// withProductVariants returns the product with its variants set.
+func (pr *PostgresRepo) withProductVariants(ctx context.Context, product domain.Product) (*domain.Product, error) {
+	products := []domain.Product{product}
+	if err := pr.withVariants(ctx, products); err != nil {
+		return nil, err
+	}
+
+	return &products[0], nil
+}
+
 func (pr *PostgresRepo) CountProducts(ctx context.Context, req params.ListProductsQueryParams) (int, error) {
 	qCount := pr.listQuery(req).Columns("count(id)")
 	qc, args, err := qCount.ToSql()
@@ -95,6 +310,44 @@ func (pr *PostgresRepo) CountProducts(ctx context.Context, req params.ListProduc
 	return countProducts, nil
 }
 
+// CountProductsByType counts the products matching req for every product type.
+// The type filter is ignored, so the counts of the other types stay visible.
+func (pr *PostgresRepo) CountProductsByType(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error) {
+	req.Types = nil
+	q := pr.listQuery(req).
+		Columns("p.product_type_name", "count(p.id)").
+		GroupBy("p.product_type_name")
+
+	qr, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.QueryContext(ctx, qr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			productType string
+			count       int
+		)
+		if err := rows.Scan(&productType, &count); err != nil {
+			return nil, err
+		}
+		counts[productType] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 func (pr *PostgresRepo) CreateProduct(ctx context.Context, req params.CreateProductRequest) (int, error) {
 	var id int
 	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
@@ -103,9 +356,9 @@ func (pr *PostgresRepo) CreateProduct(ctx context.Context, req params.CreateProd
 			return err
 		}
 
-		qInsertProduct :=
-			`INSERT INTO products("name", price, product_type_name) VALUES($1, $2, $3) RETURNING id;`
-		if err := tx.QueryRowContext(ctx, qInsertProduct, req.Name, req.Price, req.Type).Scan(&id); err != nil {
+		qInsertProduct := `INSERT INTO products("name", sku, barcode, price, currency, product_type_name)
+			VALUES($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6) RETURNING id;`
+		if err := tx.QueryRowContext(ctx, qInsertProduct, req.Name, req.SKU, req.Barcode, req.Price, req.Currency, req.Type).Scan(&id); err != nil {
 			return err
 		}
 
@@ -117,3 +370,335 @@ func (pr *PostgresRepo) CreateProduct(ctx context.Context, req params.CreateProd
 
 	return id, nil
 }
+
+// CreateProducts inserts every product in one transaction, ids are returned in the same order.
+func (pr *PostgresRepo) CreateProducts(ctx context.Context, reqs []params.CreateProductRequest) ([]int, error) {
+	ids := make([]int, 0, len(reqs))
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		qInsertProductType := `INSERT INTO product_types("name") VALUES($1) ON CONFLICT(name) DO NOTHING;`
+		stmtProductType, err := tx.PrepareContext(ctx, qInsertProductType)
+		if err != nil {
+			return err
+		}
+		defer stmtProductType.Close()
+
+		qInsertProduct := `INSERT INTO products("name", sku, barcode, price, currency, product_type_name)
+			VALUES($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6) RETURNING id;`
+		stmtProduct, err := tx.PrepareContext(ctx, qInsertProduct)
+		if err != nil {
+			return err
+		}
+		defer stmtProduct.Close()
+
+		productTypes := make(map[string]bool)
+		for _, req := range reqs {
+			if !productTypes[req.Type] {
+				if _, err := stmtProductType.ExecContext(ctx, req.Type); err != nil {
+					return err
+				}
+				productTypes[req.Type] = true
+			}
+
+			var id int
+			if err := stmtProduct.QueryRowContext(ctx, req.Name, req.SKU, req.Barcode, req.Price, req.Currency, req.Type).Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpsertProducts inserts the products in one transaction, a product whose name
+// is already taken, case insensitive, gets the new price, currency and type instead.
+// Soft deleted products are updated too but stay deleted.
+func (pr *PostgresRepo) UpsertProducts(ctx context.Context, reqs []params.CreateProductRequest) (created, updated int, err error) {
+	err = runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		qInsertProductType := `INSERT INTO product_types("name") VALUES($1) ON CONFLICT(name) DO NOTHING;`
+		stmtProductType, err := tx.PrepareContext(ctx, qInsertProductType)
+		if err != nil {
+			return err
+		}
+		defer stmtProductType.Close()
+
+		// an empty currency keeps the currency of an existing product
+		qUpdateProduct := `UPDATE products SET price = $2, currency = COALESCE(NULLIF($3, ''), currency), product_type_name = $4
+			WHERE LOWER("name") = LOWER($1);`
+		stmtUpdateProduct, err := tx.PrepareContext(ctx, qUpdateProduct)
+		if err != nil {
+			return err
+		}
+		defer stmtUpdateProduct.Close()
+
+		qInsertProduct := `INSERT INTO products("name", price, currency, product_type_name) VALUES($1, $2, $3, $4);`
+		stmtInsertProduct, err := tx.PrepareContext(ctx, qInsertProduct)
+		if err != nil {
+			return err
+		}
+		defer stmtInsertProduct.Close()
+
+		productTypes := make(map[string]bool)
+		for _, req := range reqs {
+			if !productTypes[req.Type] {
+				if _, err := stmtProductType.ExecContext(ctx, req.Type); err != nil {
+					return err
+				}
+				productTypes[req.Type] = true
+			}
+
+			res, err := stmtUpdateProduct.ExecContext(ctx, req.Name, req.Price, req.Currency, req.Type)
+			if err != nil {
+				return err
+			}
+
+			affected, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if affected > 0 {
+				updated++
+				continue
+			}
+
+			currency := req.Currency
+			if currency == "" {
+				currency = domain.DefaultCurrency
+			}
+
+			if _, err := stmtInsertProduct.ExecContext(ctx, req.Name, req.Price, currency, req.Type); err != nil {
+				return err
+			}
+			created++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
+}
+
+// ExistingProductNames returns the lowercased names already taken, soft deleted products included.
+func (pr *PostgresRepo) ExistingProductNames(ctx context.Context, names []string) ([]string, error) {
+	lowerNames := make([]string, 0, len(names))
+	for _, name := range names {
+		lowerNames = append(lowerNames, strings.ToLower(name))
+	}
+
+	q := `SELECT LOWER("name") FROM products WHERE LOWER("name") = ANY($1);`
+
+	rows, err := pr.db.QueryContext(ctx, q, pq.Array(lowerNames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	existing := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		existing = append(existing, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (pr *PostgresRepo) UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*domain.Product, error) {
+	var product domain.Product
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		qInsertProductType := `INSERT INTO product_types("name") VALUES($1) ON CONFLICT(name) DO NOTHING;`
+		if _, err := tx.ExecContext(ctx, qInsertProductType, req.Type); err != nil {
+			return err
+		}
+
+		qUpdateProduct := `UPDATE products SET "name" = $1, sku = NULLIF($2, ''), barcode = NULLIF($3, ''),
+			price = $4, currency = COALESCE(NULLIF($5, ''), currency), product_type_name = $6 WHERE id = $7 AND deleted_at IS NULL
+			RETURNING id, "name", COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at;`
+		return tx.QueryRowContext(ctx, qUpdateProduct, req.Name, req.SKU, req.Barcode, req.Price, req.Currency, req.Type, id).Scan(
+			&product.ID,
+			&product.Name,
+			&product.SKU,
+			&product.Barcode,
+			&product.Price.Amount,
+			&product.Price.Currency,
+			&product.ProductType.Name,
+			&product.CreatedAt,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.withProductVariants(ctx, product)
+}
+
+func (pr *PostgresRepo) ProductNameExists(ctx context.Context, name string, exceptID int) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM products WHERE LOWER("name") = LOWER($1) AND id <> $2);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, name, exceptID).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
+// ProductSKUExists reports whether the sku is held by another product or by a variant.
+func (pr *PostgresRepo) ProductSKUExists(ctx context.Context, sku string, exceptID int) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM products WHERE sku = $1 AND id <> $2)
+		OR EXISTS(SELECT 1 FROM product_variants WHERE sku = $1);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, sku, exceptID).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
+func (pr *PostgresRepo) ProductBarcodeExists(ctx context.Context, barcode string, exceptID int) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM products WHERE barcode = $1 AND id <> $2);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, barcode, exceptID).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
+// ExistingProductSKUs returns the skus already taken by products, soft deleted ones included, or variants.
+func (pr *PostgresRepo) ExistingProductSKUs(ctx context.Context, skus []string) ([]string, error) {
+	q := `SELECT sku FROM products WHERE sku = ANY($1) UNION SELECT sku FROM product_variants WHERE sku = ANY($1);`
+
+	return pr.existingCodes(ctx, q, skus)
+}
+
+// ExistingProductBarcodes returns the barcodes already taken, soft deleted products included.
+func (pr *PostgresRepo) ExistingProductBarcodes(ctx context.Context, barcodes []string) ([]string, error) {
+	q := `SELECT barcode FROM products WHERE barcode = ANY($1);`
+
+	return pr.existingCodes(ctx, q, barcodes)
+}
+
+func (pr *PostgresRepo) existingCodes(ctx context.Context, q string, codes []string) ([]string, error) {
+	rows, err := pr.db.QueryContext(ctx, q, pq.Array(codes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	existing := []string{}
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return nil, err
+		}
+		existing = append(existing, code)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+func (pr *PostgresRepo) DeleteProduct(ctx context.Context, id int) error {
+	q := `UPDATE products SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;`
+
+	res, err := pr.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (pr *PostgresRepo) RestoreProduct(ctx context.Context, id int) (*domain.Product, error) {
+	q := `UPDATE products SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL
+		RETURNING id, "name", COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at;`
+
+	var product domain.Product
+	err := pr.db.QueryRowContext(ctx, q, id).Scan(
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.Barcode,
+		&product.Price.Amount,
+		&product.Price.Currency,
+		&product.ProductType.Name,
+		&product.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.withProductVariants(ctx, product)
+}
+
+func (pr *PostgresRepo) SuggestProductNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	q := `SELECT "name" FROM products WHERE LOWER("name") LIKE $1 AND deleted_at IS NULL
+		ORDER BY LOWER("name") LIMIT $2;`
+
+	return pr.suggest(ctx, q, prefix, limit)
+}
+
+func (pr *PostgresRepo) SuggestProductTypeNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	q := `SELECT "name" FROM product_types WHERE LOWER("name") LIKE $1 ORDER BY LOWER("name") LIMIT $2;`
+
+	return pr.suggest(ctx, q, prefix, limit)
+}
+
+// suggest runs a prefix query written to use the text_pattern_ops indexes.
+func (pr *PostgresRepo) suggest(ctx context.Context, q string, prefix string, limit int) ([]string, error) {
+	rows, err := pr.db.QueryContext(ctx, q, escapeLike(strings.ToLower(prefix))+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// escapeLike makes the LIKE wildcards in s match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
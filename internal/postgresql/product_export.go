@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+)
+
+// exportBatchSize is the number of rows fetched from the cursor at once.
+const exportBatchSize = 500
+
+// ExportProducts calls fn for every product matching req, in the requested
+// order and without pagination. Rows are read through a server side cursor,
+// so only one batch is held in memory.
+func (pr *PostgresRepo) ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(domain.Product) error) error {
+	qr, args, err := pr.productsQuery(req).ToSql()
+	if err != nil {
+		return err
+	}
+
+	// a cursor only lives inside a transaction, commit closes it
+	return runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DECLARE export_products NO SCROLL CURSOR FOR "+qr, args...); err != nil {
+			return err
+		}
+
+		qFetch := fmt.Sprintf("FETCH %d FROM export_products", exportBatchSize)
+		for {
+			fetched, err := fetchProducts(ctx, tx, qFetch, req, fn)
+			if err != nil {
+				return err
+			}
+
+			if fetched < exportBatchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// fetchProducts reads one batch from the cursor and loads its variants before
+// fn is called, so exported products match the list and detail endpoints.
+func fetchProducts(ctx context.Context, tx *sql.Tx, qFetch string, req params.ListProductsQueryParams, fn func(domain.Product) error) (int, error) {
+	products, err := fetchBatch(ctx, tx, qFetch, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := loadVariants(ctx, tx, products); err != nil {
+		return 0, err
+	}
+
+	for _, product := range products {
+		if err := fn(product); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(products), nil
+}
+
+func fetchBatch(ctx context.Context, tx *sql.Tx, qFetch string, req params.ListProductsQueryParams) ([]domain.Product, error) {
+	rows, err := tx.QueryContext(ctx, qFetch)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]domain.Product, 0, exportBatchSize)
+	for rows.Next() {
+		product, err := scanProduct(rows, req)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, rows.Err()
+}
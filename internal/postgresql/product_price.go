@@ -0,0 +1,172 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+)
+
+// ListProductPrices returns the price history of a product, the latest price first.
+func (pr *PostgresRepo) ListProductPrices(ctx context.Context, id int) ([]domain.ProductPrice, error) {
+	q := `SELECT price, currency, effective_from FROM product_price_history
+	WHERE product_id = $1
+	ORDER BY effective_from DESC, id DESC`
+
+	rows, err := pr.db.QueryContext(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []domain.ProductPrice
+	for rows.Next() {
+		var price domain.ProductPrice
+		if err := rows.Scan(&price.Price.Amount, &price.Price.Currency, &price.EffectiveFrom); err != nil {
+			return nil, err
+		}
+		prices = append(prices, price)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (pr *PostgresRepo) CreateScheduledPrice(ctx context.Context, productID int, req params.ScheduleProductPriceRequest) (*domain.ScheduledPrice, error) {
+	q := `INSERT INTO scheduled_prices (product_id, price, currency, effective_at) VALUES ($1, $2, $3, $4)
+	RETURNING id, product_id, price, currency, effective_at, created_at`
+
+	var scheduled domain.ScheduledPrice
+	err := pr.db.QueryRowContext(ctx, q, productID, req.Price, req.Currency, req.EffectiveAt).Scan(
+		&scheduled.ID,
+		&scheduled.ProductID,
+		&scheduled.Price.Amount,
+		&scheduled.Price.Currency,
+		&scheduled.EffectiveAt,
+		&scheduled.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduled, nil
+}
+
+// ListScheduledPrices returns the price changes of a product waiting to be applied, the next one first.
+func (pr *PostgresRepo) ListScheduledPrices(ctx context.Context, productID int) ([]domain.ScheduledPrice, error) {
+	q := `SELECT id, product_id, price, currency, effective_at, created_at FROM scheduled_prices
+	WHERE product_id = $1 AND applied_at IS NULL
+	ORDER BY effective_at, id`
+
+	rows, err := pr.db.QueryContext(ctx, q, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scheduledPrices []domain.ScheduledPrice
+	for rows.Next() {
+		var scheduled domain.ScheduledPrice
+		err := rows.Scan(
+			&scheduled.ID,
+			&scheduled.ProductID,
+			&scheduled.Price.Amount,
+			&scheduled.Price.Currency,
+			&scheduled.EffectiveAt,
+			&scheduled.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		scheduledPrices = append(scheduledPrices, scheduled)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scheduledPrices, nil
+}
+
+// DeleteScheduledPrice cancels a price change which is not applied yet.
+func (pr *PostgresRepo) DeleteScheduledPrice(ctx context.Context, productID, id int) error {
+	q := `DELETE FROM scheduled_prices WHERE id = $1 AND product_id = $2 AND applied_at IS NULL`
+
+	res, err := pr.db.ExecContext(ctx, q, id, productID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ApplyDueScheduledPrices sets the prices of up to limit changes which are due at now,
+// in their effective order, and returns the applied changes.
+// Rows locked by another instance are skipped, so every change is applied once.
+func (pr *PostgresRepo) ApplyDueScheduledPrices(ctx context.Context, now time.Time, limit int) ([]domain.ScheduledPrice, error) {
+	qDue := `SELECT id, product_id, price, currency, effective_at FROM scheduled_prices
+	WHERE applied_at IS NULL AND effective_at <= $1
+	ORDER BY effective_at, id
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED`
+	// read by the price history trigger, so the change is recorded from its effective time
+	qEffectiveFrom := `SELECT set_config('app.price_effective_from', $1, TRUE)`
+	qPrice := `UPDATE products SET price = $1, currency = $2 WHERE id = $3`
+	qApplied := `UPDATE scheduled_prices SET applied_at = NOW() WHERE id = $1`
+
+	var due []domain.ScheduledPrice
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, qDue, now, limit)
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var scheduled domain.ScheduledPrice
+			if err := rows.Scan(&scheduled.ID, &scheduled.ProductID, &scheduled.Price.Amount, &scheduled.Price.Currency, &scheduled.EffectiveAt); err != nil {
+				rows.Close()
+				return err
+			}
+			due = append(due, scheduled)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, scheduled := range due {
+			if _, err := tx.ExecContext(ctx, qEffectiveFrom, scheduled.EffectiveAt.Format(time.RFC3339Nano)); err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, qPrice, scheduled.Price.Amount, scheduled.Price.Currency, scheduled.ProductID); err != nil {
+				return err
+			}
+
+			if _, err := tx.ExecContext(ctx, qApplied, scheduled.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
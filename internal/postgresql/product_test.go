@@ -2,13 +2,16 @@ package postgresql
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
 	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,9 +36,10 @@ func TestProductRepo(t *testing.T) {
 			expectedErr: false,
 			mock: func(m sqlmock.Sqlmock) {
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "price", "product_type_name", "created_at"}).
-					AddRow(1, "test", 1, "test", now)
+					NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+					AddRow(1, "test", "", "", 1, "IDR", "test", now, nil)
 				m.ExpectQuery("SELECT (.+) FROM products").WillReturnRows(rows)
+				expectNoVariants(m, qVariantsRegexp)
 			},
 			reqParams: params.ListProductsQueryParams{
 				PaginationParams: params.PaginationParams{
@@ -52,8 +56,8 @@ func TestProductRepo(t *testing.T) {
 			expectedErr: true,
 			mock: func(m sqlmock.Sqlmock) {
 				rows := sqlmock.
-					NewRows([]string{"id", "name", "price", "product_type_name", "created_at"}).
-					AddRow("a", "test", 1, "test", now)
+					NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+					AddRow("a", "test", "", "", 1, "IDR", "test", now, nil)
 				m.ExpectQuery("SELECT (.+) FROM products").WillReturnRows(rows)
 			},
 			reqParams: params.ListProductsQueryParams{
@@ -212,13 +216,14 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 			mock: func(m sqlmock.Sqlmock) {
 				mockSql.ExpectBegin()
 				mockSql.ExpectExec("INSERT INTO product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(1, 1))
-				mockSql.ExpectQuery("INSERT INTO products").WithArgs("melon", 1000, "buah").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mockSql.ExpectQuery("INSERT INTO products").WithArgs("melon", "", "", 1000, "IDR", "buah").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
 				mockSql.ExpectCommit()
 			},
 			reqParams: params.CreateProductRequest{
-				Name:  "melon",
-				Price: 1000,
-				Type:  "buah",
+				Name:     "melon",
+				Price:    1000,
+				Currency: "IDR",
+				Type:     "buah",
 			},
 			got: 5,
 		},
@@ -228,13 +233,14 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 			mock: func(m sqlmock.Sqlmock) {
 				mockSql.ExpectBegin()
 				mockSql.ExpectExec("INSERT INTO product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(1, 1))
-				mockSql.ExpectQuery("INSERT INTO products").WithArgs("melon", 1000, "buah").WillReturnError(errors.New("test"))
+				mockSql.ExpectQuery("INSERT INTO products").WithArgs("melon", "", "", 1000, "IDR", "buah").WillReturnError(errors.New("test"))
 				mockSql.ExpectRollback()
 			},
 			reqParams: params.CreateProductRequest{
-				Name:  "melon",
-				Price: 1000,
-				Type:  "buah",
+				Name:     "melon",
+				Price:    1000,
+				Currency: "IDR",
+				Type:     "buah",
 			},
 			got: 0,
 		},
@@ -247,9 +253,10 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 				mockSql.ExpectRollback()
 			},
 			reqParams: params.CreateProductRequest{
-				Name:  "melon",
-				Price: 1000,
-				Type:  "buah",
+				Name:     "melon",
+				Price:    1000,
+				Currency: "IDR",
+				Type:     "buah",
 			},
 			got: 0,
 		},
@@ -272,3 +279,964 @@ func TestProductRepo_CreateProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductRepo_CreateProducts(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	reqs := []params.CreateProductRequest{
+		{Name: "melon", Price: 1000, Currency: "IDR", Type: "buah"},
+		{Name: "apel", Price: 2000, Currency: "IDR", Type: "buah"},
+	}
+
+	mockSql.ExpectBegin()
+	prepType := mockSql.ExpectPrepare("INSERT INTO product_types")
+	prepProduct := mockSql.ExpectPrepare("INSERT INTO products")
+	prepType.ExpectExec().WithArgs("buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepProduct.ExpectQuery().WithArgs("melon", "", "", 1000, "IDR", "buah").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	prepProduct.ExpectQuery().WithArgs("apel", "", "", 2000, "IDR", "buah").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	mockSql.ExpectCommit()
+
+	ids, err := pr.CreateProducts(context.Background(), reqs)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{10, 11}, ids)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CreateProducts_Rollback(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	reqs := []params.CreateProductRequest{
+		{Name: "melon", Price: 1000, Currency: "IDR", Type: "buah"},
+		{Name: "apel", Price: 2000, Currency: "IDR", Type: "buah"},
+	}
+
+	mockSql.ExpectBegin()
+	prepType := mockSql.ExpectPrepare("INSERT INTO product_types")
+	prepProduct := mockSql.ExpectPrepare("INSERT INTO products")
+	prepType.ExpectExec().WithArgs("buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepProduct.ExpectQuery().WithArgs("melon", "", "", 1000, "IDR", "buah").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	prepProduct.ExpectQuery().WithArgs("apel", "", "", 2000, "IDR", "buah").WillReturnError(errors.New("duplicate key"))
+	mockSql.ExpectRollback()
+
+	ids, err := pr.CreateProducts(context.Background(), reqs)
+	assert.Error(t, err)
+	assert.Nil(t, ids)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_UpsertProducts(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	reqs := []params.CreateProductRequest{
+		{Name: "Melon", Price: 1000, Currency: "IDR", Type: "buah"},
+		{Name: "apel", Price: 2000, Currency: "IDR", Type: "buah"},
+		// without currency, kept when updated and the default when inserted
+		{Name: "Jeruk", Price: 300, Type: "buah"},
+		{Name: "mangga", Price: 400, Type: "buah"},
+	}
+
+	mockSql.ExpectBegin()
+	prepType := mockSql.ExpectPrepare("INSERT INTO product_types")
+	prepUpdate := mockSql.ExpectPrepare(`UPDATE products SET price = \$2, currency = COALESCE\(NULLIF\(\$3, ''\), currency\)`)
+	prepInsert := mockSql.ExpectPrepare("INSERT INTO products")
+	prepType.ExpectExec().WithArgs("buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepUpdate.ExpectExec().WithArgs("Melon", 1000, "IDR", "buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepUpdate.ExpectExec().WithArgs("apel", 2000, "IDR", "buah").WillReturnResult(sqlmock.NewResult(0, 0))
+	prepInsert.ExpectExec().WithArgs("apel", 2000, "IDR", "buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepUpdate.ExpectExec().WithArgs("Jeruk", 300, "", "buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	prepUpdate.ExpectExec().WithArgs("mangga", 400, "", "buah").WillReturnResult(sqlmock.NewResult(0, 0))
+	prepInsert.ExpectExec().WithArgs("mangga", 400, "IDR", "buah").WillReturnResult(sqlmock.NewResult(0, 1))
+	mockSql.ExpectCommit()
+
+	created, updated, err := pr.UpsertProducts(context.Background(), reqs)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 2, updated)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ExistingProductNames(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT LOWER\\(\"name\"\\) FROM products").
+		WithArgs(pq.Array([]string{"melon", "apel"})).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("apel"))
+
+	got, err := pr.ExistingProductNames(context.Background(), []string{"Melon", "Apel"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apel"}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ExistingProductBarcodes(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT barcode FROM products WHERE barcode = ANY").
+		WithArgs(pq.Array([]string{"4006381333931", "0036000291452"})).
+		WillReturnRows(sqlmock.NewRows([]string{"barcode"}).AddRow("4006381333931"))
+
+	got, err := pr.ExistingProductBarcodes(context.Background(), []string{"4006381333931", "0036000291452"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"4006381333931"}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ExportProducts(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	req := params.ListProductsQueryParams{Types: []string{"buah"}}
+	assert.NoError(t, req.Validate())
+
+	columns := []string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}
+	firstBatch := sqlmock.NewRows(columns)
+	for i := range exportBatchSize {
+		firstBatch.AddRow(i+1, "product", "", "", 1000, "IDR", "buah", time.Now(), nil)
+	}
+
+	mockSql.ExpectBegin()
+	mockSql.ExpectExec("DECLARE export_products NO SCROLL CURSOR FOR " +
+		"SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at FROM products p " +
+		"WHERE p.product_type_name IN ($1) AND p.deleted_at IS NULL ORDER BY p.id asc").
+		WithArgs("buah").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockSql.ExpectQuery("FETCH 500 FROM export_products").WillReturnRows(firstBatch)
+	expectNoVariants(mockSql, qVariantsEqual)
+	mockSql.ExpectQuery("FETCH 500 FROM export_products").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(exportBatchSize+1, "last product", "", "", 2000, "IDR", "buah", time.Now(), nil))
+	mockSql.ExpectQuery(qVariantsEqual).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "attributes", "price", "currency", "created_at"}).
+			AddRow(1, exportBatchSize+1, "LAST-1KG", []byte(`{"size":"1kg"}`), 2500, "IDR", time.Now()))
+	mockSql.ExpectCommit()
+
+	var exported int
+	var last domain.Product
+	err = pr.ExportProducts(context.Background(), req, func(product domain.Product) error {
+		exported++
+		last = product
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, exportBatchSize+1, exported)
+	assert.Equal(t, "last product", last.Name)
+	assert.Len(t, last.Variants, 1)
+	assert.Equal(t, "LAST-1KG", last.Variants[0].SKU)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ExportProducts_Stop(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	req := params.ListProductsQueryParams{}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectBegin()
+	mockSql.ExpectExec("DECLARE export_products").WillReturnResult(sqlmock.NewResult(0, 0))
+	mockSql.ExpectQuery("FETCH 500 FROM export_products").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+			AddRow(1, "melon", "", "", 1000, "IDR", "buah", time.Now(), nil).
+			AddRow(2, "apel", "", "", 2000, "IDR", "buah", time.Now(), nil))
+	expectNoVariants(mockSql, qVariantsRegexp)
+	mockSql.ExpectRollback()
+
+	errClosed := errors.New("client closed")
+	err = pr.ExportProducts(context.Background(), req, func(product domain.Product) error {
+		return errClosed
+	})
+	assert.ErrorIs(t, err, errClosed)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_GetProduct(t *testing.T) {
+	db, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+	now := time.Now()
+
+	testTable := []struct {
+		name        string
+		expectedErr bool
+		mock        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:        "success",
+			expectedErr: false,
+			mock: func(m sqlmock.Sqlmock) {
+				rows := sqlmock.
+					NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at"}).
+					AddRow(1, "melon", "", "", 1000, "IDR", "buah", now)
+				m.ExpectQuery("SELECT (.+) FROM products WHERE id").WithArgs(1).WillReturnRows(rows)
+				expectNoVariants(m, qVariantsRegexp)
+			},
+		},
+		{
+			name:        "not found",
+			expectedErr: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM products WHERE id").WithArgs(1).WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			test.mock(mockSql)
+			got, err := pr.GetProduct(context.Background(), 1)
+			if test.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, got.ID)
+				assert.Equal(t, "buah", got.ProductType.Name)
+			}
+
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_GetProductByBarcode(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	mockSql.ExpectQuery("SELECT (.+) FROM products WHERE barcode = \\$1 AND deleted_at IS NULL").
+		WithArgs("4006381333931").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at"}).
+			AddRow(1, "melon", "BUAH-001", "4006381333931", 1000, "IDR", "buah", now))
+	expectNoVariants(mockSql, qVariantsRegexp)
+
+	got, err := pr.GetProductByBarcode(context.Background(), "4006381333931")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Product{
+		ID:          1,
+		Name:        "melon",
+		SKU:         "BUAH-001",
+		Barcode:     "4006381333931",
+		Price:       domain.Money{Amount: 1000, Currency: "IDR"},
+		ProductType: domain.ProductType{Name: "buah"},
+		CreatedAt:   now,
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_UpdateProduct(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+	now := time.Now()
+	req := params.UpdateProductRequest{
+		Name:     "melon",
+		Price:    1000,
+		Currency: "IDR",
+		Type:     "buah",
+	}
+
+	testTable := []struct {
+		name        string
+		expectedErr bool
+		mock        func(sqlmock.Sqlmock)
+	}{
+		{
+			name:        "success",
+			expectedErr: false,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery("UPDATE products").WithArgs("melon", "", "", 1000, "IDR", "buah", 1).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at"}).
+						AddRow(1, "melon", "", "", 1000, "IDR", "buah", now))
+				m.ExpectCommit()
+				expectNoVariants(m, qVariantsRegexp)
+			},
+		},
+		{
+			name:        "not found",
+			expectedErr: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec("INSERT INTO product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectQuery("UPDATE products").WithArgs("melon", "", "", 1000, "IDR", "buah", 1).WillReturnError(sql.ErrNoRows)
+				m.ExpectRollback()
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			test.mock(mockSql)
+			got, err := pr.UpdateProduct(context.Background(), 1, req)
+			if test.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "melon", got.Name)
+			}
+
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_UpdateProduct_Omitted_Currency_Keeps_Stored(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+	now := time.Now()
+
+	mockSql.ExpectBegin()
+	mockSql.ExpectExec("INSERT INTO product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(1, 1))
+	mockSql.ExpectQuery(`UPDATE products (.+) currency = COALESCE\(NULLIF\(\$5, ''\), currency\)`).
+		WithArgs("melon", "", "", 1099, "", "buah", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at"}).
+			AddRow(1, "melon", "", "", 1099, "USD", "buah", now))
+	mockSql.ExpectCommit()
+	expectNoVariants(mockSql, qVariantsRegexp)
+
+	got, err := pr.UpdateProduct(context.Background(), 1, params.UpdateProductRequest{Name: "melon", Price: 1099, Type: "buah"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money{Amount: 1099, Currency: "USD"}, got.Price)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ProductNameExists(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT EXISTS").WithArgs("melon", 1).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	got, err := pr.ProductNameExists(context.Background(), "melon", 1)
+	assert.NoError(t, err)
+	assert.True(t, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ProductSKUExists(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM products WHERE sku").WithArgs("BUAH-001", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	got, err := pr.ProductSKUExists(context.Background(), "BUAH-001", 0)
+	assert.NoError(t, err)
+	assert.False(t, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_DeleteProduct(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	testTable := []struct {
+		name        string
+		expectedErr error
+		mock        func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "success",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE products SET deleted_at = NOW()").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:        "not found",
+			expectedErr: sql.ErrNoRows,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectExec("UPDATE products SET deleted_at = NOW()").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			test.mock(mockSql)
+			err := pr.DeleteProduct(context.Background(), 1)
+			assert.Equal(t, test.expectedErr, err)
+
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_RestoreProduct(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("UPDATE products SET deleted_at = NULL").WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at"}).
+			AddRow(1, "melon", "", "", 1000, "IDR", "buah", time.Now()))
+	expectNoVariants(mockSql, qVariantsRegexp)
+
+	got, err := pr.RestoreProduct(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.ID)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProducts_Keyset(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		Currency: "IDR",
+		PaginationParams: params.PaginationParams{
+			Limit: 2,
+			Sorts: []string{"price:desc"},
+			Cursor: params.EncodeCursor(
+				[]params.Sort{{Key: "price", Direction: "desc"}, {Key: "id", Direction: "asc"}},
+				[]string{"1000", "5"},
+			),
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at FROM products p "+
+		"WHERE p.currency = $1 AND p.deleted_at IS NULL AND ((p.price < $2) OR (p.price = $3 AND p.id > $4)) "+
+		"ORDER BY p.price desc, p.id asc LIMIT 2").
+		WithArgs("IDR", "1000", "1000", "5").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}))
+
+	_, err = pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProducts_Sort_Order(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		Currency: "IDR",
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+			Sorts: []string{"price:asc,name:desc", "created_at:asc"},
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at FROM products p " +
+		"WHERE p.currency = $1 AND p.deleted_at IS NULL " +
+		"ORDER BY p.price asc, p.name desc, p.created_at asc, p.id asc LIMIT 5").
+		WithArgs("IDR").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}))
+
+	_, err = pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "5:1:price:asc,name:desc,created_at:asc,id:asc", req.GetOrderingKey())
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CountProducts_Range_Filters(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	minPrice, maxPrice := 1000, 5000
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	req := params.ListProductsQueryParams{
+		Currency:      "IDR",
+		MinPrice:      &minPrice,
+		MaxPrice:      &maxPrice,
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT count(id) FROM products p "+
+		"WHERE p.currency = $1 AND p.price >= $2 AND p.price <= $3 AND p.created_at >= $4 AND p.created_at < $5 AND p.deleted_at IS NULL").
+		WithArgs("IDR", minPrice, maxPrice, after, before).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	got, err := pr.CountProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CountProductsByType(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	minPrice := 1000
+	req := params.ListProductsQueryParams{
+		Types:    []string{"buah"},
+		Currency: "IDR",
+		MinPrice: &minPrice,
+		Facets:   []string{"type"},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT p.product_type_name, count(p.id) FROM products p "+
+		"WHERE p.currency = $1 AND p.price >= $2 AND p.deleted_at IS NULL GROUP BY p.product_type_name").
+		WithArgs("IDR", minPrice).
+		WillReturnRows(sqlmock.NewRows([]string{"product_type_name", "count"}).
+			AddRow("buah", 2).
+			AddRow("sayuran", 5))
+
+	got, err := pr.CountProductsByType(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"buah": 2, "sayuran": 5}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProducts_In_Stock_At(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		InStockAt: 3,
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at FROM products p " +
+		"WHERE EXISTS (SELECT 1 FROM store_inventory si\n" +
+		"\tWHERE si.store_id = $1 AND si.product_id = p.id AND si.quantity > 0) " +
+		"AND p.deleted_at IS NULL ORDER BY p.id asc LIMIT 5").
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+			AddRow(101, "Sawi", "", "", 2500, "IDR", "sayuran", time.Now(), nil))
+	expectNoVariants(mockSql, qVariantsEqual)
+
+	got, err := pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CountProducts_Include_Subtypes(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		Types:           []string{"Buah"},
+		IncludeSubtypes: true,
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT count(id) FROM products p WHERE p.product_type_name IN (" +
+		"WITH RECURSIVE subtypes AS (\n" +
+		"\tSELECT \"name\" FROM product_types WHERE \"name\" = ANY($1)\n" +
+		"\tUNION\n" +
+		"\tSELECT pt.\"name\" FROM product_types pt JOIN subtypes s ON pt.parent_name = s.\"name\"\n" +
+		") SELECT \"name\" FROM subtypes) AND p.deleted_at IS NULL").
+		WithArgs(pq.Array([]string{"buah"})).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	got, err := pr.CountProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProducts_Fulltext(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		Search:     "kopi luwak",
+		SearchMode: params.SearchModeFulltext,
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at, ts_rank(p.search_vector, query) AS relevance " +
+		"FROM products p CROSS JOIN websearch_to_tsquery('simple', $1) AS query " +
+		"WHERE p.search_vector @@ query AND p.deleted_at IS NULL " +
+		"ORDER BY ts_rank(p.search_vector, query) desc, p.id asc LIMIT 5").
+		WithArgs("kopi luwak").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at", "relevance"}).
+			AddRow(1, "kopi luwak", "", "", 1000, "IDR", "snack", time.Now(), nil, 0.0607927))
+	expectNoVariants(mockSql, qVariantsEqual)
+
+	got, err := pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0607927, got[0].Relevance)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProducts_Fuzzy(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	testTable := []struct {
+		name          string
+		minSimilarity float64
+		query         string
+	}{
+		{
+			name: "default threshold uses the trigram operator",
+			query: "SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at, similarity(LOWER(p.name), term) AS relevance " +
+				"FROM products p CROSS JOIN LOWER($1) AS term " +
+				"WHERE LOWER(p.name) % term AND similarity(LOWER(p.name), term) >= $2 AND p.deleted_at IS NULL " +
+				"ORDER BY similarity(LOWER(p.name), term) desc, p.id asc LIMIT 5",
+		},
+		{
+			name:          "lower threshold skips the trigram operator",
+			minSimilarity: 0.1,
+			query: "SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at, similarity(LOWER(p.name), term) AS relevance " +
+				"FROM products p CROSS JOIN LOWER($1) AS term " +
+				"WHERE similarity(LOWER(p.name), term) >= $2 AND p.deleted_at IS NULL " +
+				"ORDER BY similarity(LOWER(p.name), term) desc, p.id asc LIMIT 5",
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			req := params.ListProductsQueryParams{
+				Search:        "kangkong",
+				SearchMode:    params.SearchModeFuzzy,
+				MinSimilarity: test.minSimilarity,
+				PaginationParams: params.PaginationParams{
+					Limit: 5,
+				},
+			}
+			assert.NoError(t, req.Validate())
+
+			mockSql.ExpectQuery(test.query).
+				WithArgs("kangkong", req.MinSimilarity).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at", "relevance"}).
+					AddRow(101, "Kangkung", "", "", 2000, "IDR", "sayuran", time.Now(), nil, 0.5))
+			expectNoVariants(mockSql, qVariantsEqual)
+
+			got, err := pr.ListProducts(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, 0.5, got[0].Relevance)
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_ListProducts_As_Of(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	minPrice := 1000
+	req := params.ListProductsQueryParams{
+		AsOf:     &asOf,
+		Currency: "IDR",
+		MinPrice: &minPrice,
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+			Sorts: []string{"price:desc"},
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), ph.price, ph.currency, product_type_name, created_at, deleted_at FROM products p "+
+		"JOIN LATERAL (SELECT h.price, h.currency FROM product_price_history h\n"+
+		"\tWHERE h.product_id = p.id AND h.effective_from <= $1\n"+
+		"\tORDER BY h.effective_from DESC, h.id DESC LIMIT 1) AS ph ON TRUE "+
+		"WHERE ph.currency = $2 AND ph.price >= $3 AND p.deleted_at IS NULL ORDER BY ph.price desc, p.id asc LIMIT 5").
+		WithArgs(asOf, "IDR", minPrice).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+			AddRow(101, "Sawi", "", "", 2500, "IDR", "sayuran", time.Now(), nil))
+	expectNoVariants(mockSql, qVariantsEqual)
+
+	got, err := pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Money{Amount: 2500, Currency: "IDR"}, got[0].Price)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ListProductPrices(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	changedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	mockSql.ExpectQuery("SELECT price, currency, effective_from FROM product_price_history").WithArgs(1).WillReturnRows(
+		sqlmock.NewRows([]string{"price", "currency", "effective_from"}).
+			AddRow(150, "SGD", changedAt).
+			AddRow(1000, "IDR", createdAt))
+
+	got, err := pr.ListProductPrices(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ProductPrice{
+		{Price: domain.Money{Amount: 150, Currency: "SGD"}, EffectiveFrom: changedAt},
+		{Price: domain.Money{Amount: 1000, Currency: "IDR"}, EffectiveFrom: createdAt},
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_DeleteScheduledPrice(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectExec("DELETE FROM scheduled_prices").WithArgs(3, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = pr.DeleteScheduledPrice(context.Background(), 1, 3)
+	assert.Equal(t, sql.ErrNoRows, err)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_ApplyDueScheduledPrices(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Date(2026, 10, 19, 0, 1, 0, 0, time.UTC)
+	effectiveAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	mockSql.ExpectBegin()
+	mockSql.ExpectQuery("SELECT id, product_id, price, currency, effective_at FROM scheduled_prices .* FOR UPDATE SKIP LOCKED").
+		WithArgs(now, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "price", "currency", "effective_at"}).
+			AddRow(3, 101, 2500, "IDR", effectiveAt))
+	mockSql.ExpectExec("SELECT set_config\\('app.price_effective_from'").
+		WithArgs("2026-10-19T00:00:00Z").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mockSql.ExpectExec("UPDATE products SET price").WithArgs(2500, "IDR", 101).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockSql.ExpectExec("UPDATE scheduled_prices SET applied_at").WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mockSql.ExpectCommit()
+
+	got, err := pr.ApplyDueScheduledPrices(context.Background(), now, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.ScheduledPrice{{ID: 3, ProductID: 101, Price: domain.Money{Amount: 2500, Currency: "IDR"}, EffectiveAt: effectiveAt}}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_SuggestProductNames(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	testTable := []struct {
+		name        string
+		prefix      string
+		expectedErr bool
+		mock        func(sqlmock.Sqlmock)
+		got         []string
+	}{
+		{
+			name:   "success",
+			prefix: "Sa",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM products WHERE").WithArgs("sa%", 5).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Sawi"))
+			},
+			got: []string{"Sawi"},
+		},
+		{
+			name:   "wildcards are escaped",
+			prefix: "50%_",
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM products WHERE").WithArgs(`50\%\_%`, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+			got: []string{},
+		},
+		{
+			name:        "failed",
+			prefix:      "sa",
+			expectedErr: true,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("SELECT (.+) FROM products WHERE").WithArgs("sa%", 5).WillReturnError(errors.New("test"))
+			},
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			test.mock(mockSql)
+			got, err := pr.SuggestProductNames(context.Background(), test.prefix, 5)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.got, got)
+
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+// the variants query run for the returned products, for both query matchers
+const (
+	qVariantsEqual = "SELECT id, product_id, COALESCE(sku, ''), attributes, price, currency, created_at " +
+		"FROM product_variants WHERE product_id = ANY($1) ORDER BY id;"
+	qVariantsRegexp = "SELECT (.+) FROM product_variants WHERE product_id = ANY"
+)
+
+func expectNoVariants(m sqlmock.Sqlmock, query string) {
+	m.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "attributes", "price", "currency", "created_at"}))
+}
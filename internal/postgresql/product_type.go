@@ -0,0 +1,248 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+)
+
+// qSelectProductType counts the products that are not soft deleted for every type.
+const qSelectProductType = `SELECT pt."name", COALESCE(pt.parent_name, ''), pt.created_at, count(p.id) FROM product_types pt
+	LEFT JOIN products p ON p.product_type_name = pt."name" AND p.deleted_at IS NULL`
+
+func (pr *PostgresRepo) ListProductTypes(ctx context.Context) ([]domain.ProductType, error) {
+	q := qSelectProductType + ` GROUP BY pt."name" ORDER BY pt."name" ASC;`
+
+	rows, err := pr.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productTypes := []domain.ProductType{}
+	for rows.Next() {
+		var productType domain.ProductType
+		if err := rows.Scan(
+			&productType.Name,
+			&productType.ParentName,
+			&productType.CreatedAt,
+			&productType.TotalProducts,
+		); err != nil {
+			return nil, err
+		}
+		productTypes = append(productTypes, productType)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return productTypes, nil
+}
+
+func (pr *PostgresRepo) GetProductType(ctx context.Context, name string) (*domain.ProductType, error) {
+	q := qSelectProductType + ` WHERE pt."name" = $1 GROUP BY pt."name";`
+
+	var productType domain.ProductType
+	err := pr.db.QueryRowContext(ctx, q, name).Scan(
+		&productType.Name,
+		&productType.ParentName,
+		&productType.CreatedAt,
+		&productType.TotalProducts,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productType, nil
+}
+
+// ProductTypeExists reports whether the type exists, without counting its products.
+func (pr *PostgresRepo) ProductTypeExists(ctx context.Context, name string) (bool, error) {
+	q := `SELECT EXISTS (SELECT 1 FROM product_types WHERE "name" = $1);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, name).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
+// ProductTypeNames returns the name of every type, without counting their products.
+func (pr *PostgresRepo) ProductTypeNames(ctx context.Context) ([]string, error) {
+	q := `SELECT "name" FROM product_types ORDER BY "name" ASC;`
+
+	rows, err := pr.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (pr *PostgresRepo) CreateProductType(ctx context.Context, name, parent string) (*domain.ProductType, error) {
+	q := `INSERT INTO product_types("name", parent_name) VALUES($1, NULLIF($2, ''))
+		RETURNING "name", COALESCE(parent_name, ''), created_at;`
+
+	var productType domain.ProductType
+	err := pr.db.QueryRowContext(ctx, q, name, parent).Scan(
+		&productType.Name,
+		&productType.ParentName,
+		&productType.CreatedAt,
+	)
+	if isUniqueViolation(err) {
+		return nil, domain.ErrProductTypeExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &productType, nil
+}
+
+// qAncestors walks up from the type in $1, UNION stops on a cycle.
+const qAncestors = `WITH RECURSIVE ancestors AS (
+		SELECT "name", parent_name FROM product_types WHERE "name" = $1
+		UNION
+		SELECT pt."name", pt.parent_name FROM product_types pt JOIN ancestors a ON pt."name" = a.parent_name
+	)`
+
+// MoveProductType sets the parent of the type, an empty parent makes it a root category.
+// The moved type and the ancestors of the new parent are locked before the cycle check,
+// so two concurrent moves cannot each pass the check and put the types under one another.
+func (pr *PostgresRepo) MoveProductType(ctx context.Context, name, parent string) error {
+	return runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		qLock := qAncestors + `
+	SELECT "name" FROM product_types WHERE "name" = $2 OR "name" IN (SELECT "name" FROM ancestors)
+	ORDER BY "name" FOR UPDATE;`
+
+		rows, err := tx.QueryContext(ctx, qLock, parent, name)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		locked := map[string]bool{}
+		for rows.Next() {
+			var lockedName string
+			if err := rows.Scan(&lockedName); err != nil {
+				return err
+			}
+			locked[lockedName] = true
+		}
+
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if !locked[name] {
+			return sql.ErrNoRows
+		}
+
+		if parent != "" {
+			if !locked[parent] {
+				return domain.ErrParentNotFound
+			}
+
+			// read again once locked, a move committed meanwhile may have changed the ancestors
+			qCycle := qAncestors + `
+	SELECT EXISTS (SELECT 1 FROM ancestors WHERE "name" = $2);`
+
+			var cycle bool
+			if err := tx.QueryRowContext(ctx, qCycle, parent, name).Scan(&cycle); err != nil {
+				return err
+			}
+
+			if cycle {
+				return domain.ErrProductTypeCycle
+			}
+		}
+
+		q := `UPDATE product_types SET parent_name = NULLIF($1, '') WHERE "name" = $2;`
+		_, err = tx.ExecContext(ctx, q, parent, name)
+		return err
+	})
+}
+
+func (pr *PostgresRepo) CountChildProductTypes(ctx context.Context, name string) (int, error) {
+	q := `SELECT count("name") FROM product_types WHERE parent_name = $1;`
+
+	var total int
+	if err := pr.db.QueryRowContext(ctx, q, name).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// RenameProductType renames the type, the products follow through the ON UPDATE CASCADE foreign key.
+func (pr *PostgresRepo) RenameProductType(ctx context.Context, name, newName string) error {
+	q := `UPDATE product_types SET "name" = $1 WHERE "name" = $2;`
+
+	res, err := pr.db.ExecContext(ctx, q, newName, name)
+	if isUniqueViolation(err) {
+		return domain.ErrProductTypeExists
+	}
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CountProductsOfType counts every product referencing the type, soft deleted ones included.
+func (pr *PostgresRepo) CountProductsOfType(ctx context.Context, name string) (int, error) {
+	q := `SELECT count(id) FROM products WHERE product_type_name = $1;`
+
+	var total int
+	if err := pr.db.QueryRowContext(ctx, q, name).Scan(&total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (pr *PostgresRepo) DeleteProductType(ctx context.Context, name string) error {
+	q := `DELETE FROM product_types WHERE "name" = $1;`
+
+	res, err := pr.db.ExecContext(ctx, q, name)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductTypeRepo_ListProductTypes(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	mockSql.ExpectQuery("SELECT (.+) FROM product_types pt LEFT JOIN products p (.+) GROUP BY").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "parent_name", "created_at", "count"}).
+			AddRow("buah", "", now, 2).
+			AddRow("buah impor", "buah", now, 0))
+
+	got, err := pr.ListProductTypes(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "buah", got[0].Name)
+	assert.Equal(t, 2, got[0].TotalProducts)
+	assert.Equal(t, "buah", got[1].ParentName)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductTypeRepo_ProductTypeExists_And_Names(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT EXISTS (.+) FROM product_types WHERE").WithArgs("sayurab").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mockSql.ExpectQuery(`SELECT "name" FROM product_types ORDER BY`).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("buah").AddRow("sayuran"))
+
+	exist, err := pr.ProductTypeExists(context.Background(), "sayurab")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+
+	names, err := pr.ProductTypeNames(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"buah", "sayuran"}, names)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductTypeRepo_RenameProductType(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	testTable := []struct {
+		name        string
+		expectedErr error
+		affected    int64
+		execErr     error
+	}{
+		{name: "success", affected: 1},
+		{name: "not found", affected: 0, expectedErr: sql.ErrNoRows},
+		{name: "renamed concurrently", execErr: &pq.Error{Code: "23505"}, expectedErr: domain.ErrProductTypeExists},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			exec := mockSql.ExpectExec("UPDATE product_types SET").
+				WithArgs("sayuran", "sayurab")
+			if test.execErr != nil {
+				exec.WillReturnError(test.execErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, test.affected))
+			}
+
+			err := pr.RenameProductType(context.Background(), "sayurab", "sayuran")
+			assert.Equal(t, test.expectedErr, err)
+
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductTypeRepo_DeleteProductType(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT count\\(id\\) FROM products WHERE product_type_name").
+		WithArgs("buah").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mockSql.ExpectExec("DELETE FROM product_types").WithArgs("buah").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	total, err := pr.CountProductsOfType(context.Background(), "buah")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.NoError(t, pr.DeleteProductType(context.Background(), "buah"))
+
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductTypeRepo_MoveProductType(t *testing.T) {
+	tests := []struct {
+		name    string
+		parent  string
+		locked  []string
+		cycle   bool
+		wantErr string
+	}{
+		{name: "success", parent: "buah", locked: []string{"buah", "buah impor"}},
+		{name: "to root", locked: []string{"buah impor"}},
+		{name: "not found", parent: "buah", locked: []string{"buah"}, wantErr: sql.ErrNoRows.Error()},
+		{name: "parent not found", parent: "sayur", locked: []string{"buah impor"}, wantErr: domain.ErrParentNotFound.Error()},
+		{name: "under its descendant", parent: "apel", locked: []string{"apel", "buah", "buah impor"}, cycle: true,
+			wantErr: domain.ErrProductTypeCycle.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbSql, mockSql, err := sqlmock.New()
+			if err != nil {
+				t.Error(err)
+			}
+			defer dbSql.Close()
+			pr := NewRepo(dbSql)
+
+			mockSql.ExpectBegin()
+			rows := sqlmock.NewRows([]string{"name"})
+			for _, name := range tt.locked {
+				rows.AddRow(name)
+			}
+			mockSql.ExpectQuery(`WITH RECURSIVE ancestors (.+) SELECT "name" FROM product_types (.+) FOR UPDATE`).
+				WithArgs(tt.parent, "buah impor").
+				WillReturnRows(rows)
+			if tt.parent != "" && (tt.wantErr == "" || tt.cycle) {
+				mockSql.ExpectQuery(`WITH RECURSIVE ancestors (.+) SELECT EXISTS`).
+					WithArgs(tt.parent, "buah impor").
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(tt.cycle))
+			}
+			if tt.wantErr == "" {
+				mockSql.ExpectExec("UPDATE product_types SET parent_name = NULLIF").
+					WithArgs(tt.parent, "buah impor").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mockSql.ExpectCommit()
+			} else {
+				mockSql.ExpectRollback()
+			}
+
+			err = pr.MoveProductType(context.Background(), "buah impor", tt.parent)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/lib/pq"
+)
+
+// qHasVariant matches the products p with a variant holding all of the given attributes.
+const qHasVariant = `EXISTS (SELECT 1 FROM product_variants pv
+	WHERE pv.product_id = p.id AND pv.attributes @> ?::jsonb)`
+
+// jsonAttributes encodes the attributes of a variant for the JSONB column,
+// a map of strings cannot fail to encode.
+func jsonAttributes(attributes map[string]string) string {
+	b, _ := json.Marshal(attributes)
+	return string(b)
+}
+
+func scanVariant(row scanner) (domain.Variant, error) {
+	var (
+		variant    domain.Variant
+		attributes []byte
+	)
+	err := row.Scan(
+		&variant.ID,
+		&variant.ProductID,
+		&variant.SKU,
+		&attributes,
+		&variant.Price.Amount,
+		&variant.Price.Currency,
+		&variant.CreatedAt,
+	)
+	if err != nil {
+		return variant, err
+	}
+
+	err = json.Unmarshal(attributes, &variant.Attributes)
+	return variant, err
+}
+
+// withVariants sets the variants of every product with a single query.
+func (pr *PostgresRepo) withVariants(ctx context.Context, products []domain.Product) error {
+	return loadVariants(ctx, pr.db, products)
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// loadVariants is withVariants on db, which can be the transaction of a cursor.
+func loadVariants(ctx context.Context, db queryer, products []domain.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	q := `SELECT id, product_id, COALESCE(sku, ''), attributes, price, currency, created_at
+		FROM product_variants WHERE product_id = ANY($1) ORDER BY id;`
+
+	rows, err := db.QueryContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	variants := make(map[int][]domain.Variant)
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			return err
+		}
+		variants[variant.ProductID] = append(variants[variant.ProductID], variant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range products {
+		products[i].Variants = variants[products[i].ID]
+	}
+
+	return nil
+}
+
+func (pr *PostgresRepo) CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*domain.Variant, error) {
+	q := `INSERT INTO product_variants(product_id, sku, attributes, price, currency)
+		VALUES($1, NULLIF($2, ''), $3, $4, $5)
+		RETURNING id, product_id, COALESCE(sku, ''), attributes, price, currency, created_at;`
+
+	row := pr.db.QueryRowContext(ctx, q, productID, req.SKU, jsonAttributes(req.Attributes), req.Price, req.Currency)
+	variant, err := scanVariant(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (pr *PostgresRepo) UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*domain.Variant, error) {
+	q := `UPDATE product_variants SET sku = NULLIF($3, ''), attributes = $4, price = $5, currency = $6, updated_at = NOW()
+		WHERE id = $1 AND product_id = $2
+		RETURNING id, product_id, COALESCE(sku, ''), attributes, price, currency, created_at;`
+
+	row := pr.db.QueryRowContext(ctx, q, id, productID, req.SKU, jsonAttributes(req.Attributes), req.Price, req.Currency)
+	variant, err := scanVariant(row)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+func (pr *PostgresRepo) DeleteVariant(ctx context.Context, productID, id int) error {
+	q := `DELETE FROM product_variants WHERE id = $1 AND product_id = $2;`
+
+	res, err := pr.db.ExecContext(ctx, q, id, productID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// VariantAttributesExist reports whether another variant of the product has exactly these attributes.
+func (pr *PostgresRepo) VariantAttributesExist(ctx context.Context, productID int, attributes map[string]string, exceptID int) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM product_variants WHERE product_id = $1 AND attributes = $2::jsonb AND id <> $3);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, productID, jsonAttributes(attributes), exceptID).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
+
+// VariantSKUExists reports whether the sku is held by another variant or by a product.
+func (pr *PostgresRepo) VariantSKUExists(ctx context.Context, sku string, exceptID int) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM product_variants WHERE sku = $1 AND id <> $2)
+		OR EXISTS(SELECT 1 FROM products WHERE sku = $1);`
+
+	var exist bool
+	if err := pr.db.QueryRowContext(ctx, q, sku, exceptID).Scan(&exist); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
@@ -0,0 +1,181 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductRepo_ListProducts_Variant(t *testing.T) {
+	db, mockSql, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Error(err)
+	}
+	defer db.Close()
+	pr := NewRepo(db)
+
+	req := params.ListProductsQueryParams{
+		Variants: []string{"Flavor:Cokelat", "pack:6 pcs"},
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+		},
+	}
+	assert.NoError(t, req.Validate())
+
+	createdAt := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	mockSql.ExpectQuery("SELECT id, name, COALESCE(sku, ''), COALESCE(barcode, ''), price, currency, product_type_name, created_at, deleted_at FROM products p " +
+		"WHERE EXISTS (SELECT 1 FROM product_variants pv\n" +
+		"\tWHERE pv.product_id = p.id AND pv.attributes @> $1::jsonb) " +
+		"AND p.deleted_at IS NULL ORDER BY p.id asc LIMIT 5").
+		WithArgs(`{"flavor":"cokelat","pack":"6 pcs"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "sku", "barcode", "price", "currency", "product_type_name", "created_at", "deleted_at"}).
+			AddRow(101, "Donat", "", "", 5000, "IDR", "snack", createdAt, nil).
+			AddRow(102, "Roti", "", "", 8000, "IDR", "snack", createdAt, nil))
+	mockSql.ExpectQuery(qVariantsEqual).
+		WithArgs(pq.Array([]int{101, 102})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "attributes", "price", "currency", "created_at"}).
+			AddRow(1, 101, "DONAT-C6", `{"flavor": "cokelat", "pack": "6 pcs"}`, 30000, "IDR", createdAt).
+			AddRow(2, 101, "", `{"flavor": "keju", "pack": "6 pcs"}`, 32000, "IDR", createdAt).
+			AddRow(3, 102, "", `{"flavor": "cokelat", "pack": "6 pcs"}`, 45000, "IDR", createdAt))
+
+	got, err := pr.ListProducts(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, []domain.Variant{
+		{
+			ID:         1,
+			ProductID:  101,
+			SKU:        "DONAT-C6",
+			Attributes: map[string]string{"flavor": "cokelat", "pack": "6 pcs"},
+			Price:      domain.Money{Amount: 30000, Currency: "IDR"},
+			CreatedAt:  createdAt,
+		},
+		{
+			ID:         2,
+			ProductID:  101,
+			Attributes: map[string]string{"flavor": "keju", "pack": "6 pcs"},
+			Price:      domain.Money{Amount: 32000, Currency: "IDR"},
+			CreatedAt:  createdAt,
+		},
+	}, got[0].Variants)
+	assert.Len(t, got[1].Variants, 1)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CreateVariant(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	req := params.CreateVariantRequest{
+		Attributes: map[string]string{"flavor": "cokelat"},
+		Price:      5000,
+		Currency:   "IDR",
+	}
+	now := time.Now()
+	mockSql.ExpectQuery("INSERT INTO product_variants").
+		WithArgs(101, "", `{"flavor":"cokelat"}`, 5000, "IDR").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "sku", "attributes", "price", "currency", "created_at"}).
+			AddRow(1, 101, "", `{"flavor": "cokelat"}`, 5000, "IDR", now))
+
+	got, err := pr.CreateVariant(context.Background(), 101, req)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Variant{
+		ID:         1,
+		ProductID:  101,
+		Attributes: map[string]string{"flavor": "cokelat"},
+		Price:      domain.Money{Amount: 5000, Currency: "IDR"},
+		CreatedAt:  now,
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_UpdateVariant_Not_Found(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	req := params.UpdateVariantRequest{
+		Attributes: map[string]string{"flavor": "cokelat"},
+		Price:      5000,
+		Currency:   "IDR",
+		SKU:        "DONAT-C1",
+	}
+	mockSql.ExpectQuery("UPDATE product_variants SET").
+		WithArgs(1, 101, "DONAT-C1", `{"flavor":"cokelat"}`, 5000, "IDR").
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := pr.UpdateVariant(context.Background(), 101, 1, req)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_DeleteVariant(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "success", affected: 1},
+		{name: "not found", affected: 0, wantErr: sql.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbSql, mockSql, err := sqlmock.New()
+			if err != nil {
+				t.Error(err)
+			}
+			defer dbSql.Close()
+			pr := NewRepo(dbSql)
+
+			mockSql.ExpectExec("DELETE FROM product_variants").WithArgs(1, 101).WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			err = pr.DeleteVariant(context.Background(), 101, 1)
+			assert.Equal(t, tt.wantErr, err)
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_VariantAttributesExist(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM product_variants WHERE product_id").
+		WithArgs(101, `{"flavor":"cokelat","pack":"6 pcs"}`, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	got, err := pr.VariantAttributesExist(context.Background(), 101, map[string]string{"pack": "6 pcs", "flavor": "cokelat"}, 0)
+	assert.NoError(t, err)
+	assert.True(t, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
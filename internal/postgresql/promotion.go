@@ -0,0 +1,150 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/lib/pq"
+)
+
+const qSelectPromotion = `SELECT id, "name", discount_type, discount_value, COALESCE(currency, ''), COALESCE(product_id, 0), COALESCE(product_type_name, ''),
+	starts_at, ends_at, created_at FROM promotions`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPromotion(row scanner, dest ...any) (domain.Promotion, error) {
+	var promotion domain.Promotion
+	err := row.Scan(append([]any{
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.DiscountType,
+		&promotion.DiscountValue,
+		&promotion.Currency,
+		&promotion.ProductID,
+		&promotion.ProductTypeName,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.CreatedAt,
+	}, dest...)...)
+	return promotion, err
+}
+
+func (pr *PostgresRepo) ListPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	q := qSelectPromotion + ` ORDER BY starts_at DESC, id DESC`
+
+	rows, err := pr.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []domain.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// ListPromotionsEndingAfter returns the promotions still active or starting
+// after t, with the descendants of their product type, for pricing.
+func (pr *PostgresRepo) ListPromotionsEndingAfter(ctx context.Context, t time.Time) ([]domain.Promotion, error) {
+	q := `SELECT pm.id, pm."name", pm.discount_type, pm.discount_value, COALESCE(pm.currency, ''), COALESCE(pm.product_id, 0), COALESCE(pm.product_type_name, ''),
+	pm.starts_at, pm.ends_at, pm.created_at,
+	ARRAY(WITH RECURSIVE subtypes AS (
+		SELECT "name" FROM product_types WHERE "name" = pm.product_type_name
+		UNION
+		SELECT pt."name" FROM product_types pt JOIN subtypes s ON pt.parent_name = s."name"
+	) SELECT "name" FROM subtypes)
+	FROM promotions pm WHERE pm.ends_at > $1 ORDER BY pm.id`
+
+	rows, err := pr.db.QueryContext(ctx, q, t)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []domain.Promotion{}
+	for rows.Next() {
+		var productTypes []string
+		promotion, err := scanPromotion(rows, pq.Array(&productTypes))
+		if err != nil {
+			return nil, err
+		}
+		promotion.ProductTypes = productTypes
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (pr *PostgresRepo) GetPromotion(ctx context.Context, id int) (*domain.Promotion, error) {
+	q := qSelectPromotion + ` WHERE id = $1`
+
+	promotion, err := scanPromotion(pr.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (pr *PostgresRepo) CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*domain.Promotion, error) {
+	q := `INSERT INTO promotions ("name", discount_type, discount_value, currency, product_id, product_type_name, starts_at, ends_at)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, 0), NULLIF($6, ''), $7, $8)
+	RETURNING id, "name", discount_type, discount_value, COALESCE(currency, ''), COALESCE(product_id, 0), COALESCE(product_type_name, ''),
+	starts_at, ends_at, created_at`
+
+	promotion, err := scanPromotion(pr.db.QueryRowContext(ctx, q,
+		req.Name,
+		req.DiscountType,
+		req.DiscountValue,
+		req.Currency,
+		req.ProductID,
+		req.ProductType,
+		req.StartsAt,
+		req.EndsAt,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (pr *PostgresRepo) DeletePromotion(ctx context.Context, id int) error {
+	q := `DELETE FROM promotions WHERE id = $1`
+
+	res, err := pr.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
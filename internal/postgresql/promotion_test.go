@@ -0,0 +1,84 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/stretchr/testify/assert"
+)
+
+var promotionColumns = []string{"id", "name", "discount_type", "discount_value", "currency", "product_id", "product_type_name", "starts_at", "ends_at", "created_at"}
+
+func TestPromotionRepo_ListPromotionsEndingAfter(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	startsAt := now.Add(-time.Hour)
+	endsAt := now.Add(time.Hour)
+	mockSql.ExpectQuery("WITH RECURSIVE subtypes (.+) FROM promotions pm WHERE pm.ends_at > \\$1").WithArgs(now).WillReturnRows(
+		sqlmock.NewRows(append(promotionColumns, "product_types")).
+			AddRow(1, "catalog", "percentage", 10, "", 0, "", startsAt, endsAt, startsAt, "{}").
+			AddRow(2, "buah", "fixed", 500, "IDR", 0, "buah", startsAt, endsAt, startsAt, `{buah,"buah impor"}`))
+
+	got, err := pr.ListPromotionsEndingAfter(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Promotion{
+		{ID: 1, Name: "catalog", DiscountType: "percentage", DiscountValue: 10, ProductTypes: []string{}, StartsAt: startsAt, EndsAt: endsAt, CreatedAt: startsAt},
+		{ID: 2, Name: "buah", DiscountType: "fixed", DiscountValue: 500, Currency: "IDR", ProductTypeName: "buah", ProductTypes: []string{"buah", "buah impor"}, StartsAt: startsAt, EndsAt: endsAt, CreatedAt: startsAt},
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPromotionRepo_CreatePromotion(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	startsAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(24 * time.Hour)
+	req := params.CreatePromotionRequest{Name: "flash sale", DiscountType: "fixed", DiscountValue: 500, Currency: "IDR", ProductID: 101, StartsAt: startsAt, EndsAt: endsAt}
+	mockSql.ExpectQuery("INSERT INTO promotions (.+) RETURNING").
+		WithArgs("flash sale", "fixed", 500, "IDR", 101, "", startsAt, endsAt).
+		WillReturnRows(sqlmock.NewRows(promotionColumns).
+			AddRow(1, "flash sale", "fixed", 500, "IDR", 101, "", startsAt, endsAt, startsAt))
+
+	got, err := pr.CreatePromotion(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.ID)
+	assert.Equal(t, 101, got.ProductID)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPromotionRepo_DeletePromotion(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	mockSql.ExpectExec("DELETE FROM promotions").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = pr.DeletePromotion(context.Background(), 1)
+	assert.Equal(t, sql.ErrNoRows, err)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
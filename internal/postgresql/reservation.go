@@ -0,0 +1,175 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+)
+
+const qReservationColumns = `id, store_id, product_id, quantity, status, expires_at, created_at, updated_at`
+
+func scanReservation(row scanner) (domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := row.Scan(
+		&reservation.ID,
+		&reservation.StoreID,
+		&reservation.ProductID,
+		&reservation.Quantity,
+		&reservation.Status,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+	)
+	return reservation, err
+}
+
+func (pr *PostgresRepo) GetReservation(ctx context.Context, id int) (*domain.Reservation, error) {
+	q := `SELECT ` + qReservationColumns + ` FROM stock_reservations WHERE id = $1`
+
+	reservation, err := scanReservation(pr.db.QueryRowContext(ctx, q, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// CreateReservation takes the quantity out of the store inventory and holds it
+// until expiresAt. The inventory row is locked while the stock is checked, so
+// concurrent reservations of the last items never oversell. It returns the
+// stock left, or sql.ErrNoRows when the stock is lower than the quantity.
+func (pr *PostgresRepo) CreateReservation(ctx context.Context, req params.CreateReservationRequest, expiresAt time.Time) (*domain.Reservation, int, error) {
+	qStock := `SELECT quantity FROM store_inventory WHERE store_id = $1 AND product_id = $2 FOR UPDATE`
+	qTake := `UPDATE store_inventory SET quantity = quantity - $3, updated_at = NOW() WHERE store_id = $1 AND product_id = $2`
+	qReserve := `INSERT INTO stock_reservations (store_id, product_id, quantity, expires_at) VALUES ($1, $2, $3, $4)
+	RETURNING ` + qReservationColumns
+
+	var reservation domain.Reservation
+	var stock int
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		if err := tx.QueryRowContext(ctx, qStock, req.StoreID, req.ProductID).Scan(&stock); err != nil {
+			return err
+		}
+
+		if stock < req.Quantity {
+			return sql.ErrNoRows
+		}
+
+		if _, err := tx.ExecContext(ctx, qTake, req.StoreID, req.ProductID, req.Quantity); err != nil {
+			return err
+		}
+
+		var err error
+		reservation, err = scanReservation(tx.QueryRowContext(ctx, qReserve, req.StoreID, req.ProductID, req.Quantity, expiresAt))
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &reservation, stock - req.Quantity, nil
+}
+
+// ConfirmReservation confirms a pending reservation which is not expired, the
+// stock stays out of the inventory. It returns sql.ErrNoRows otherwise.
+func (pr *PostgresRepo) ConfirmReservation(ctx context.Context, id int) (*domain.Reservation, error) {
+	q := `UPDATE stock_reservations SET status = $2, updated_at = NOW()
+	WHERE id = $1 AND status = $3 AND expires_at > NOW()
+	RETURNING ` + qReservationColumns
+
+	reservation, err := scanReservation(pr.db.QueryRowContext(ctx, q, id, params.ReservationConfirmed, params.ReservationPending))
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation, nil
+}
+
+// CancelReservation cancels a pending reservation and puts its quantity back
+// in the store inventory. It returns the stock of the store after the release,
+// or sql.ErrNoRows when the reservation is not pending.
+func (pr *PostgresRepo) CancelReservation(ctx context.Context, id int) (*domain.Reservation, int, error) {
+	var reservation domain.Reservation
+	var stock int
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		var err error
+		reservation, stock, err = releaseReservation(ctx, tx, id, params.ReservationCancelled)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &reservation, stock, nil
+}
+
+// ExpireReservations releases up to limit pending reservations which expired
+// at now and returns them. Rows locked by a confirmation, a cancellation or
+// another instance are skipped, so every reservation is released once.
+func (pr *PostgresRepo) ExpireReservations(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	qDue := `SELECT id FROM stock_reservations
+	WHERE status = $1 AND expires_at <= $2
+	ORDER BY expires_at, id
+	LIMIT $3
+	FOR UPDATE SKIP LOCKED`
+
+	var expired []domain.Reservation
+	err := runInTx(ctx, pr.db, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, qDue, params.ReservationPending, now, limit)
+		if err != nil {
+			return err
+		}
+
+		var ids []int
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			reservation, _, err := releaseReservation(ctx, tx, id, params.ReservationExpired)
+			if err != nil {
+				return err
+			}
+			expired = append(expired, reservation)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+// releaseReservation moves a pending reservation to status and puts its
+// quantity back in the store inventory. The update locks the reservation, so a
+// concurrent release finds it no longer pending.
+func releaseReservation(ctx context.Context, tx *sql.Tx, id int, status string) (domain.Reservation, int, error) {
+	qRelease := `UPDATE stock_reservations SET status = $2, updated_at = NOW()
+	WHERE id = $1 AND status = $3
+	RETURNING ` + qReservationColumns
+	qPutBack := `UPDATE store_inventory SET quantity = quantity + $3, updated_at = NOW() WHERE store_id = $1 AND product_id = $2
+	RETURNING quantity`
+
+	reservation, err := scanReservation(tx.QueryRowContext(ctx, qRelease, id, status, params.ReservationPending))
+	if err != nil {
+		return reservation, 0, err
+	}
+
+	var stock int
+	err = tx.QueryRowContext(ctx, qPutBack, reservation.StoreID, reservation.ProductID, reservation.Quantity).Scan(&stock)
+	return reservation, stock, err
+}
@@ -0,0 +1,117 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	"github.com/stretchr/testify/assert"
+)
+
+var reservationColumns = []string{"id", "store_id", "product_id", "quantity", "status", "expires_at", "created_at", "updated_at"}
+
+func TestReservationRepo_CreateReservation(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	expiresAt := now.Add(15 * time.Minute)
+	req := params.CreateReservationRequest{StoreID: 1, ProductID: 101, Quantity: 3}
+
+	t.Run("success", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery("SELECT quantity FROM store_inventory (.+) FOR UPDATE").WithArgs(1, 101).
+			WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(5))
+		mockSql.ExpectExec("UPDATE store_inventory SET quantity = quantity - \\$3").WithArgs(1, 101, 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mockSql.ExpectQuery("INSERT INTO stock_reservations").WithArgs(1, 101, 3, expiresAt).
+			WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow(7, 1, 101, 3, "pending", expiresAt, now, now))
+		mockSql.ExpectCommit()
+
+		got, stock, err := pr.CreateReservation(context.Background(), req, expiresAt)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, stock)
+		assert.Equal(t, &domain.Reservation{
+			ID: 7, StoreID: 1, ProductID: 101, Quantity: 3, Status: "pending", ExpiresAt: expiresAt, CreatedAt: now, UpdatedAt: now,
+		}, got)
+		if err := mockSql.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("not enough stock", func(t *testing.T) {
+		mockSql.ExpectBegin()
+		mockSql.ExpectQuery("SELECT quantity FROM store_inventory (.+) FOR UPDATE").WithArgs(1, 101).
+			WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(2))
+		mockSql.ExpectRollback()
+
+		got, _, err := pr.CreateReservation(context.Background(), req, expiresAt)
+		assert.Equal(t, sql.ErrNoRows, err)
+		assert.Nil(t, got)
+		if err := mockSql.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestReservationRepo_CancelReservation(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	mockSql.ExpectBegin()
+	mockSql.ExpectQuery("UPDATE stock_reservations SET status = \\$2").WithArgs(7, "cancelled", "pending").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow(7, 1, 101, 3, "cancelled", now, now, now))
+	mockSql.ExpectQuery("UPDATE store_inventory SET quantity = quantity \\+ \\$3").WithArgs(1, 101, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
+	mockSql.ExpectCommit()
+
+	got, stock, err := pr.CancelReservation(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stock)
+	assert.Equal(t, "cancelled", got.Status)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReservationRepo_ExpireReservations(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	expiresAt := now.Add(-time.Minute)
+	mockSql.ExpectBegin()
+	mockSql.ExpectQuery("SELECT id FROM stock_reservations .* FOR UPDATE SKIP LOCKED").WithArgs("pending", now, 500).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mockSql.ExpectQuery("UPDATE stock_reservations SET status = \\$2").WithArgs(7, "expired", "pending").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow(7, 1, 101, 3, "expired", expiresAt, now, now))
+	mockSql.ExpectQuery("UPDATE store_inventory SET quantity = quantity \\+ \\$3").WithArgs(1, 101, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
+	mockSql.ExpectCommit()
+
+	got, err := pr.ExpireReservations(context.Background(), now, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Reservation{
+		{ID: 7, StoreID: 1, ProductID: 101, Quantity: 3, Status: "expired", ExpiresAt: expiresAt, CreatedAt: now, UpdatedAt: now},
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,129 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+)
+
+func (pr *PostgresRepo) ListStores(ctx context.Context) ([]domain.Store, error) {
+	q := `SELECT id, "name", created_at FROM stores ORDER BY id`
+
+	rows, err := pr.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stores := []domain.Store{}
+	for rows.Next() {
+		var store domain.Store
+		if err := rows.Scan(&store.ID, &store.Name, &store.CreatedAt); err != nil {
+			return nil, err
+		}
+		stores = append(stores, store)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stores, nil
+}
+
+func (pr *PostgresRepo) GetStore(ctx context.Context, id int) (*domain.Store, error) {
+	q := `SELECT id, "name", created_at FROM stores WHERE id = $1`
+
+	var store domain.Store
+	if err := pr.db.QueryRowContext(ctx, q, id).Scan(&store.ID, &store.Name, &store.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &store, nil
+}
+
+func (pr *PostgresRepo) CreateStore(ctx context.Context, name string) (*domain.Store, error) {
+	q := `INSERT INTO stores("name") VALUES($1) RETURNING id, "name", created_at`
+
+	var store domain.Store
+	if err := pr.db.QueryRowContext(ctx, q, name).Scan(&store.ID, &store.Name, &store.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &store, nil
+}
+
+// StoreNameExists reports whether the name is taken, case insensitive.
+func (pr *PostgresRepo) StoreNameExists(ctx context.Context, name string) (bool, error) {
+	q := `SELECT EXISTS(SELECT 1 FROM stores WHERE LOWER("name") = LOWER($1))`
+
+	var exists bool
+	if err := pr.db.QueryRowContext(ctx, q, name).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// ListStockLevels returns the stock of the products of a store which are not
+// soft deleted, out of stock products included.
+func (pr *PostgresRepo) ListStockLevels(ctx context.Context, storeID int) ([]domain.StockLevel, error) {
+	q := `SELECT si.store_id, si.product_id, p."name", si.quantity, si.updated_at FROM store_inventory si
+	JOIN products p ON p.id = si.product_id AND p.deleted_at IS NULL
+	WHERE si.store_id = $1 ORDER BY si.product_id`
+
+	rows, err := pr.db.QueryContext(ctx, q, storeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []domain.StockLevel{}
+	for rows.Next() {
+		var level domain.StockLevel
+		if err := rows.Scan(
+			&level.StoreID,
+			&level.ProductID,
+			&level.ProductName,
+			&level.Quantity,
+			&level.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+// AdjustStock adds delta to the stock of the product at the store in a single
+// statement, so concurrent adjustments never lose an update. It returns
+// sql.ErrNoRows when a negative delta is more than the stock.
+func (pr *PostgresRepo) AdjustStock(ctx context.Context, storeID, productID, delta int) (*domain.StockLevel, error) {
+	q := `INSERT INTO store_inventory (store_id, product_id, quantity) VALUES ($1, $2, $3)
+	ON CONFLICT (store_id, product_id) DO UPDATE SET quantity = store_inventory.quantity + EXCLUDED.quantity, updated_at = NOW()
+	RETURNING store_id, product_id, quantity, updated_at`
+	if delta < 0 {
+		// a missing row has no stock to take out
+		q = `UPDATE store_inventory SET quantity = quantity + $3, updated_at = NOW()
+		WHERE store_id = $1 AND product_id = $2 AND quantity + $3 >= 0
+		RETURNING store_id, product_id, quantity, updated_at`
+	}
+
+	var level domain.StockLevel
+	err := pr.db.QueryRowContext(ctx, q, storeID, productID, delta).Scan(
+		&level.StoreID,
+		&level.ProductID,
+		&level.Quantity,
+		&level.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &level, nil
+}
@@ -0,0 +1,119 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreRepo_CreateStore(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	mockSql.ExpectQuery("INSERT INTO stores").WithArgs("Kemang").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at"}).AddRow(1, "Kemang", now))
+
+	got, err := pr.CreateStore(context.Background(), "Kemang")
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.Store{ID: 1, Name: "Kemang", CreatedAt: now}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStoreRepo_ListStockLevels(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+
+	now := time.Now()
+	mockSql.ExpectQuery("SELECT (.+) FROM store_inventory si JOIN products p (.+) WHERE si.store_id = \\$1").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"store_id", "product_id", "name", "quantity", "updated_at"}).
+			AddRow(1, 101, "Sawi", 4, now).
+			AddRow(1, 102, "Kangkung", 0, now))
+
+	got, err := pr.ListStockLevels(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.StockLevel{
+		{StoreID: 1, ProductID: 101, ProductName: "Sawi", Quantity: 4, UpdatedAt: now},
+		{StoreID: 1, ProductID: 102, ProductName: "Kangkung", Quantity: 0, UpdatedAt: now},
+	}, got)
+	if err := mockSql.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStoreRepo_AdjustStock(t *testing.T) {
+	dbSql, mockSql, err := sqlmock.New()
+	if err != nil {
+		t.Error(err)
+	}
+	defer dbSql.Close()
+	pr := NewRepo(dbSql)
+	now := time.Now()
+
+	testTable := []struct {
+		name  string
+		delta int
+		mock  func(m sqlmock.Sqlmock)
+		want  *domain.StockLevel
+		err   error
+	}{
+		{
+			name:  "restock upserts the row",
+			delta: 5,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("INSERT INTO store_inventory (.+) ON CONFLICT \\(store_id, product_id\\) DO UPDATE").
+					WithArgs(1, 101, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "product_id", "quantity", "updated_at"}).AddRow(1, 101, 9, now))
+			},
+			want: &domain.StockLevel{StoreID: 1, ProductID: 101, Quantity: 9, UpdatedAt: now},
+		},
+		{
+			name:  "sale takes out the stock",
+			delta: -4,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("UPDATE store_inventory SET quantity = quantity \\+ \\$3").
+					WithArgs(1, 101, -4).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "product_id", "quantity", "updated_at"}).AddRow(1, 101, 5, now))
+			},
+			want: &domain.StockLevel{StoreID: 1, ProductID: 101, Quantity: 5, UpdatedAt: now},
+		},
+		{
+			name:  "not enough stock",
+			delta: -10,
+			mock: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery("UPDATE store_inventory SET quantity = quantity \\+ \\$3").
+					WithArgs(1, 101, -10).
+					WillReturnError(sql.ErrNoRows)
+			},
+			err: sql.ErrNoRows,
+		},
+	}
+
+	for _, test := range testTable {
+		t.Run(test.name, func(t *testing.T) {
+			test.mock(mockSql)
+
+			got, err := pr.AdjustStock(context.Background(), 1, 101, test.delta)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.want, got)
+			if err := mockSql.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
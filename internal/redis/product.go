@@ -11,8 +11,12 @@ import (
 )
 
 const (
-	countProductsKeys = "count"
-	prefixProduct     = "product:"
+	countProductsKeys   = "count"
+	typeFacetsKeys      = "facets:type"
+	prefixProduct       = "product:"
+	prefixProductDetail = "product_detail:"
+	prefixSuggestion    = "suggest:"
+	prefixBarcode       = "barcode:"
 )
 
 func (pr *RedisRepo) CacheProducts(ctx context.Context, req params.ListProductsQueryParams, countProducts int, listProducts []domain.Product) error {
@@ -60,11 +64,124 @@ func (pr *RedisRepo) GetCachedProductCount(ctx context.Context, req params.ListP
 	return total, nil
 }
 
+func (pr *RedisRepo) CacheProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams, counts map[string]int) error {
+	keyRaw := prefixProduct + req.GetParamsKey()
+
+	str, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+
+	return pr.cache.HSet(ctx, keyRaw, typeFacetsKeys, str).Err()
+}
+
+func (pr *RedisRepo) GetCachedProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error) {
+	keyRaw := prefixProduct + req.GetParamsKey()
+
+	res, err := pr.cache.HGet(ctx, keyRaw, typeFacetsKeys).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var counts map[string]int
+	if err := json.Unmarshal([]byte(res), &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (pr *RedisRepo) CacheProduct(ctx context.Context, product domain.Product) error {
+	keyRaw := prefixProductDetail + strconv.Itoa(product.ID)
+
+	str, err := json.Marshal(product)
+	if err != nil {
+		return err
+	}
+
+	return pr.cache.Set(ctx, keyRaw, str, 0).Err()
+}
+
+func (pr *RedisRepo) GetCachedProduct(ctx context.Context, id int) (*domain.Product, error) {
+	keyRaw := prefixProductDetail + strconv.Itoa(id)
+
+	res, err := pr.cache.Get(ctx, keyRaw).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var product domain.Product
+	if err := json.Unmarshal([]byte(res), &product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (pr *RedisRepo) DeleteCachedProduct(ctx context.Context, id int) error {
+	return pr.cache.Del(ctx, prefixProductDetail+strconv.Itoa(id)).Err()
+}
+
+// CacheBarcode maps the barcode to the product id, the product itself is
+// cached under its detail key.
+func (pr *RedisRepo) CacheBarcode(ctx context.Context, barcode string, id int) error {
+	return pr.cache.Set(ctx, prefixBarcode+barcode, id, 0).Err()
+}
+
+func (pr *RedisRepo) GetCachedBarcode(ctx context.Context, barcode string) (int, error) {
+	res, err := pr.cache.Get(ctx, prefixBarcode+barcode).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.Atoi(res)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert product id: %w", err)
+	}
+	return id, nil
+}
+
+func (pr *RedisRepo) DeleteCachedBarcode(ctx context.Context, barcode string) error {
+	return pr.cache.Del(ctx, prefixBarcode+barcode).Err()
+}
+
+func (pr *RedisRepo) CacheSuggestions(ctx context.Context, req params.SuggestProductsQueryParams, res params.SuggestProductsResponse) error {
+	str, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return pr.cache.Set(ctx, prefixSuggestion+req.GetCacheKey(), str, 0).Err()
+}
+
+func (pr *RedisRepo) GetCachedSuggestions(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error) {
+	res, err := pr.cache.Get(ctx, prefixSuggestion+req.GetCacheKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions params.SuggestProductsResponse
+	if err := json.Unmarshal([]byte(res), &suggestions); err != nil {
+		return nil, err
+	}
+	return &suggestions, nil
+}
+
+func (pr *RedisRepo) FlushSuggestions(ctx context.Context) error {
+	return pr.flush(ctx, prefixSuggestion+"*", "string")
+}
+
+func (pr *RedisRepo) FlushProductDetails(ctx context.Context) error {
+	return pr.flush(ctx, prefixProductDetail+"*", "string")
+}
+
 func (pr *RedisRepo) FlushAllProducts(ctx context.Context) error {
+	return pr.flush(ctx, prefixProduct+"*", "hash")
+}
+
+// flush deletes every key of keyType matching pattern.
+func (pr *RedisRepo) flush(ctx context.Context, pattern string, keyType string) error {
 	var cursor uint64
-	pattern := prefixProduct + "*"
 	for {
-		keys, nextCursor, err := pr.cache.ScanType(ctx, cursor, pattern, 100, "hash").Result()
+		keys, nextCursor, err := pr.cache.ScanType(ctx, cursor, pattern, 100, keyType).Result()
 		if err != nil {
 			return err
 		}
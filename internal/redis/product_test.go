@@ -156,6 +156,27 @@ func TestProductRepo_GetCachedProductCount(t *testing.T) {
 	}
 }
 
+func TestProductRepo_GetCachedProductTypeFacets(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	req := params.ListProductsQueryParams{Facets: []string{"type"}}
+	req.Validate()
+	keyRaw := prefixProduct + req.GetParamsKey()
+
+	mockRedis.ExpectHSet(keyRaw, typeFacetsKeys, []byte(`{"buah":2}`)).SetVal(1)
+	assert.NoError(t, pr.CacheProductTypeFacets(context.Background(), req, map[string]int{"buah": 2}))
+
+	mockRedis.ExpectHGet(keyRaw, typeFacetsKeys).SetVal(`{"buah":2}`)
+	got, err := pr.GetCachedProductTypeFacets(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"buah": 2}, got)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestProductRepo_FlushAllProducts(t *testing.T) {
 	dbRedis, mockRedis := redismock.NewClientMock()
 	pr := NewRepo(dbRedis)
@@ -207,3 +228,200 @@ func TestProductRepo_FlushAllProducts(t *testing.T) {
 		})
 	}
 }
+
+func TestProductRepo_CacheProduct(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	product := domain.Product{ID: 1}
+	jsonProduct, _ := json.Marshal(product)
+
+	mockRedis.ExpectSet(prefixProductDetail+"1", jsonProduct, 0).SetVal("OK")
+
+	err := pr.CacheProduct(context.Background(), product)
+	assert.NoError(t, err)
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_GetCachedProduct(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	product := domain.Product{ID: 1}
+	jsonProduct, _ := json.Marshal(product)
+
+	tableTest := []struct {
+		name      string
+		expectErr bool
+		mock      func(m redismock.ClientMock)
+	}{
+		{
+			name:      "success",
+			expectErr: false,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixProductDetail + "1").SetVal(string(jsonProduct))
+			},
+		},
+		{
+			name:      "failed",
+			expectErr: true,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixProductDetail + "1").SetErr(errors.New("redis error"))
+			},
+		},
+		{
+			name:      "failed when parsing",
+			expectErr: true,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixProductDetail + "1").SetVal("a")
+			},
+		},
+	}
+
+	for _, tt := range tableTest {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockRedis)
+			got, err := pr.GetCachedProduct(context.Background(), 1)
+			if tt.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &product, got)
+			}
+			if err := mockRedis.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_DeleteCachedProduct(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	mockRedis.ExpectDel(prefixProductDetail + "1").SetVal(1)
+
+	err := pr.DeleteCachedProduct(context.Background(), 1)
+	assert.NoError(t, err)
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_CacheBarcode(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	mockRedis.ExpectSet(prefixBarcode+"8991002101135", 1, 0).SetVal("OK")
+
+	err := pr.CacheBarcode(context.Background(), "8991002101135", 1)
+	assert.NoError(t, err)
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_GetCachedBarcode(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	tableTest := []struct {
+		name      string
+		expectErr bool
+		mock      func(m redismock.ClientMock)
+	}{
+		{
+			name:      "success",
+			expectErr: false,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixBarcode + "8991002101135").SetVal("1")
+			},
+		},
+		{
+			name:      "failed",
+			expectErr: true,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixBarcode + "8991002101135").SetErr(errors.New("redis error"))
+			},
+		},
+		{
+			name:      "failed when parsing",
+			expectErr: true,
+			mock: func(m redismock.ClientMock) {
+				m.ExpectGet(prefixBarcode + "8991002101135").SetVal("a")
+			},
+		},
+	}
+
+	for _, tt := range tableTest {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockRedis)
+			got, err := pr.GetCachedBarcode(context.Background(), "8991002101135")
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, got)
+			}
+			if err := mockRedis.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestProductRepo_CacheSuggestions(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	req := params.SuggestProductsQueryParams{Query: "sa"}
+	req.Validate()
+	suggestions := params.SuggestProductsResponse{Products: []string{"Sawi"}, Types: []string{"sayuran"}}
+	jsonSuggestions, _ := json.Marshal(suggestions)
+
+	mockRedis.ExpectSet(prefixSuggestion+req.GetCacheKey(), jsonSuggestions, 0).SetVal("OK")
+	mockRedis.ExpectGet(prefixSuggestion + req.GetCacheKey()).SetVal(string(jsonSuggestions))
+
+	err := pr.CacheSuggestions(context.Background(), req, suggestions)
+	assert.NoError(t, err)
+
+	got, err := pr.GetCachedSuggestions(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &suggestions, got)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_FlushProductDetails(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	mockRedis.ExpectScanType(0, prefixProductDetail+"*", 100, "string").SetVal([]string{"product_detail:1"}, 0)
+	mockRedis.ExpectDel("product_detail:1").SetVal(1)
+
+	err := pr.FlushProductDetails(context.Background())
+	assert.NoError(t, err)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProductRepo_FlushSuggestions(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	mockRedis.ExpectScanType(0, prefixSuggestion+"*", 100, "string").SetVal([]string{"a"}, 0)
+	mockRedis.ExpectDel("a").SetVal(1)
+
+	err := pr.FlushSuggestions(context.Background())
+	assert.NoError(t, err)
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,38 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+)
+
+// promotionsKey holds the promotions which had not ended when they were cached,
+// the active ones are picked on every read so the key stays valid until a change.
+const promotionsKey = "promotions"
+
+func (pr *RedisRepo) CachePromotions(ctx context.Context, promotions []domain.Promotion) error {
+	str, err := json.Marshal(promotions)
+	if err != nil {
+		return err
+	}
+
+	return pr.cache.Set(ctx, promotionsKey, str, 0).Err()
+}
+
+func (pr *RedisRepo) GetCachedPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	res, err := pr.cache.Get(ctx, promotionsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var promotions []domain.Promotion
+	if err := json.Unmarshal([]byte(res), &promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (pr *RedisRepo) FlushPromotions(ctx context.Context) error {
+	return pr.cache.Del(ctx, promotionsKey).Err()
+}
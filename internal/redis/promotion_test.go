@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionRepo_CachePromotions(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	promotions := []domain.Promotion{{
+		ID:            1,
+		Name:          "payday",
+		DiscountType:  "percentage",
+		DiscountValue: 10,
+		ProductTypes:  []string{"buah", "apel"},
+		StartsAt:      time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+		EndsAt:        time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC),
+	}}
+	jsonPromotions, _ := json.Marshal(promotions)
+
+	mockRedis.ExpectSet(promotionsKey, jsonPromotions, 0).SetVal("OK")
+	mockRedis.ExpectGet(promotionsKey).SetVal(string(jsonPromotions))
+
+	err := pr.CachePromotions(context.Background(), promotions)
+	assert.NoError(t, err)
+
+	got, err := pr.GetCachedPromotions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, promotions, got)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPromotionRepo_FlushPromotions(t *testing.T) {
+	dbRedis, mockRedis := redismock.NewClientMock()
+	pr := NewRepo(dbRedis)
+
+	mockRedis.ExpectDel(promotionsKey).SetVal(1)
+
+	err := pr.FlushPromotions(context.Background())
+	assert.NoError(t, err)
+
+	if err := mockRedis.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -4,7 +4,14 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/elangreza/lion-superindo/internal/domain"
 	"github.com/elangreza/lion-superindo/internal/params"
@@ -16,7 +23,37 @@ type (
 	DbRepo interface {
 		ListProducts(ctx context.Context, req params.ListProductsQueryParams) ([]domain.Product, error)
 		CountProducts(ctx context.Context, req params.ListProductsQueryParams) (int, error)
+		CountProductsByType(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error)
 		CreateProduct(ctx context.Context, req params.CreateProductRequest) (int, error)
+		CreateProducts(ctx context.Context, reqs []params.CreateProductRequest) ([]int, error)
+		UpsertProducts(ctx context.Context, reqs []params.CreateProductRequest) (created, updated int, err error)
+		ExistingProductNames(ctx context.Context, names []string) ([]string, error)
+		ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(domain.Product) error) error
+		GetProduct(ctx context.Context, id int) (*domain.Product, error)
+		GetProductByBarcode(ctx context.Context, barcode string) (*domain.Product, error)
+		ListProductPrices(ctx context.Context, id int) ([]domain.ProductPrice, error)
+		CreateScheduledPrice(ctx context.Context, productID int, req params.ScheduleProductPriceRequest) (*domain.ScheduledPrice, error)
+		ListScheduledPrices(ctx context.Context, productID int) ([]domain.ScheduledPrice, error)
+		DeleteScheduledPrice(ctx context.Context, productID, id int) error
+		ApplyDueScheduledPrices(ctx context.Context, now time.Time, limit int) ([]domain.ScheduledPrice, error)
+		UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*domain.Product, error)
+		ProductNameExists(ctx context.Context, name string, exceptID int) (bool, error)
+		ProductSKUExists(ctx context.Context, sku string, exceptID int) (bool, error)
+		ProductBarcodeExists(ctx context.Context, barcode string, exceptID int) (bool, error)
+		ExistingProductSKUs(ctx context.Context, skus []string) ([]string, error)
+		ExistingProductBarcodes(ctx context.Context, barcodes []string) ([]string, error)
+		CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*domain.Variant, error)
+		UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*domain.Variant, error)
+		DeleteVariant(ctx context.Context, productID, id int) error
+		VariantAttributesExist(ctx context.Context, productID int, attributes map[string]string, exceptID int) (bool, error)
+		VariantSKUExists(ctx context.Context, sku string, exceptID int) (bool, error)
+		DeleteProduct(ctx context.Context, id int) error
+		RestoreProduct(ctx context.Context, id int) (*domain.Product, error)
+		SuggestProductNames(ctx context.Context, prefix string, limit int) ([]string, error)
+		SuggestProductTypeNames(ctx context.Context, prefix string, limit int) ([]string, error)
+		ProductTypeExists(ctx context.Context, name string) (bool, error)
+		ProductTypeNames(ctx context.Context) ([]string, error)
+		ListPromotionsEndingAfter(ctx context.Context, t time.Time) ([]domain.Promotion, error)
 	}
 
 	CacheRepo interface {
@@ -24,18 +61,40 @@ type (
 		CacheProducts(ctx context.Context, req params.ListProductsQueryParams, countProducts int, listProducts []domain.Product) error
 		GetCachedProducts(ctx context.Context, req params.ListProductsQueryParams) (listProducts []domain.Product, err error)
 		GetCachedProductCount(ctx context.Context, req params.ListProductsQueryParams) (countProducts int, err error)
+		CacheProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams, counts map[string]int) error
+		GetCachedProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error)
+		CacheProduct(ctx context.Context, product domain.Product) error
+		GetCachedProduct(ctx context.Context, id int) (*domain.Product, error)
+		DeleteCachedProduct(ctx context.Context, id int) error
+		FlushProductDetails(ctx context.Context) error
+		CacheBarcode(ctx context.Context, barcode string, id int) error
+		GetCachedBarcode(ctx context.Context, barcode string) (int, error)
+		DeleteCachedBarcode(ctx context.Context, barcode string) error
+		CacheSuggestions(ctx context.Context, req params.SuggestProductsQueryParams, res params.SuggestProductsResponse) error
+		GetCachedSuggestions(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error)
+		FlushSuggestions(ctx context.Context) error
+		CachePromotions(ctx context.Context, promotions []domain.Promotion) error
+		GetCachedPromotions(ctx context.Context) ([]domain.Promotion, error)
+		FlushPromotions(ctx context.Context) error
+	}
+
+	ProductServiceConfig struct {
+		// StrictProductType rejects unknown product types, by default they are created
+		StrictProductType bool
 	}
 
 	ProductService struct {
 		db    DbRepo
 		cache CacheRepo
+		cfg   ProductServiceConfig
 	}
 )
 
-func NewProductService(repo DbRepo, cache CacheRepo) *ProductService {
+func NewProductService(repo DbRepo, cache CacheRepo, cfg ProductServiceConfig) *ProductService {
 	return &ProductService{
 		db:    repo,
 		cache: cache,
+		cfg:   cfg,
 	}
 }
 
@@ -79,6 +138,15 @@ func (ps *ProductService) ListProducts(ctx context.Context, req params.ListProdu
 
 	res := params.ListProductsResponses{}
 
+	// facets ignore the type filter, so they can be non empty without products
+	if req.HasFacet(params.FacetType) {
+		typeFacets, err := ps.productTypeFacets(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		res.Facets = map[string][]params.FacetCount{params.FacetType: typeFacets}
+	}
+
 	if countProducts == 0 {
 		return &res, nil
 	}
@@ -86,22 +154,167 @@ func (ps *ProductService) ListProducts(ctx context.Context, req params.ListProdu
 	res.TotalData = countProducts
 	res.TotalPage = (countProducts + int(req.Limit) - 1) / int(req.Limit)
 
+	promotions, err := ps.activePromotions(ctx, req.AsOf)
+	if err != nil {
+		return nil, err
+	}
+
 	res.Products = make([]params.ProductResponse, 0, len(products))
 	for _, product := range products {
-		res.Products = append(res.Products, params.ProductResponse{
-			ID:        product.ID,
-			Name:      product.Name,
-			Price:     product.Price,
-			Type:      product.ProductType.Name,
-			CreatedAt: product.CreatedAt,
-		})
+		productRes := newProductResponse(product)
+		if req.SearchMode == params.SearchModeFuzzy {
+			productRes.Similarity = &product.Relevance
+		}
+		applyPromotions(&productRes, promotions)
+		res.Products = append(res.Products, productRes)
+	}
+
+	// a full page means there may be more rows after the last one
+	if len(products) > 0 && len(products) == req.Limit {
+		res.NextCursor = nextCursor(req.GetSorts(), products[len(products)-1])
+	}
+
+	return &res, nil
+}
+
+func (ps *ProductService) productTypeFacets(ctx context.Context, req params.ListProductsQueryParams) ([]params.FacetCount, error) {
+	counts, err := ps.cache.GetCachedProductTypeFacets(ctx, req)
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("cache error (type facets): %w", err)
+	}
+
+	if err == redis.Nil {
+		counts, err = ps.db.CountProductsByType(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("db error (type facets): %w", err)
+		}
+
+		if err = ps.cache.CacheProductTypeFacets(ctx, req, counts); err != nil {
+			return nil, err
+		}
+	}
+
+	facets := make([]params.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, params.FacetCount{Value: value, Count: count})
+	}
+
+	// most common types first, ties by name so the order is stable
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+
+	return facets, nil
+}
+
+func (ps *ProductService) GetProduct(ctx context.Context, id int) (*params.ProductResponse, error) {
+	product, err := ps.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions, err := ps.activePromotions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newProductResponse(*product)
+	applyPromotions(&res, promotions)
+	return &res, nil
+}
+
+// getProduct returns the cached product, or the not found error of a missing or deleted product.
+func (ps *ProductService) getProduct(ctx context.Context, id int) (*domain.Product, error) {
+	product, err := ps.cache.GetCachedProduct(ctx, id)
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("cache error: %w", err)
+	}
+
+	if err == redis.Nil {
+		product, err = ps.db.GetProduct(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundError{
+				Message: fmt.Sprintf("product %d", id),
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+
+		if err = ps.cache.CacheProduct(ctx, *product); err != nil {
+			return nil, err
+		}
+	}
+
+	return product, nil
+}
+
+// GetProductByBarcode reads the product id of the barcode from its own cache
+// key before postgres, so scanning a product again stays off the database.
+func (ps *ProductService) GetProductByBarcode(ctx context.Context, barcode string) (*params.ProductResponse, error) {
+	product, err := ps.productByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
 	}
 
+	promotions, err := ps.activePromotions(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newProductResponse(*product)
+	applyPromotions(&res, promotions)
 	return &res, nil
 }
 
+func (ps *ProductService) productByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	id, err := ps.cache.GetCachedBarcode(ctx, barcode)
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("cache error: %w", err)
+	}
+
+	if err == nil {
+		product, err := ps.getProduct(ctx, id)
+		if err != nil && !errors.As(err, &errs.NotFoundError{}) {
+			return nil, err
+		}
+		// the product may have been deleted or given another barcode since
+		if err == nil && product.Barcode == barcode {
+			return product, nil
+		}
+
+		if err := ps.cache.DeleteCachedBarcode(ctx, barcode); err != nil {
+			return nil, fmt.Errorf("failed to delete cached barcode: %w", err)
+		}
+	}
+
+	product, err := ps.db.GetProductByBarcode(ctx, barcode)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("product with barcode %s", barcode),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if err = ps.cache.CacheBarcode(ctx, barcode, product.ID); err != nil {
+		return nil, err
+	}
+
+	if err = ps.cache.CacheProduct(ctx, *product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
 func (ps *ProductService) CreateProduct(ctx context.Context, req params.CreateProductRequest) (*params.CreateProductResponse, error) {
-	products, err := ps.db.CountProducts(ctx, params.ListProductsQueryParams{Search: req.Name})
+	// soft deleted products still hold their name
+	products, err := ps.db.CountProducts(ctx, params.ListProductsQueryParams{Search: req.Name, IncludeDeleted: true})
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +325,14 @@ func (ps *ProductService) CreateProduct(ctx context.Context, req params.CreatePr
 		}
 	}
 
+	if err := ps.checkProductCodes(ctx, req, 0); err != nil {
+		return nil, err
+	}
+
+	if err := ps.checkProductType(ctx, req.Type); err != nil {
+		return nil, err
+	}
+
 	id, err := ps.db.CreateProduct(ctx, req)
 	if err != nil {
 		return nil, err
@@ -121,5 +342,399 @@ func (ps *ProductService) CreateProduct(ctx context.Context, req params.CreatePr
 		return nil, fmt.Errorf("failed to flush cache: %w", err)
 	}
 
+	if err := ps.cache.FlushSuggestions(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush suggestions: %w", err)
+	}
+
 	return &params.CreateProductResponse{ID: id}, nil
 }
+
+// BulkCreateProducts validates every product on its own and inserts the valid
+// ones in a single transaction. The list caches are flushed once at the end.
+func (ps *ProductService) BulkCreateProducts(ctx context.Context, req params.BulkCreateProductsRequest) (*params.BulkCreateProductsResponse, error) {
+	res := &params.BulkCreateProductsResponse{
+		Results: make([]params.BulkCreateProductResult, len(req.Products)),
+	}
+
+	var productTypes []string
+	if ps.cfg.StrictProductType {
+		var err error
+		productTypes, err = ps.productTypeNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// names are unique case insensitive, see ProductNameExists
+	seen := make(map[string]bool)
+	seenSKUs := make(map[string]bool)
+	seenBarcodes := make(map[string]bool)
+	valid := []int{}
+	for i := range req.Products {
+		product := &req.Products[i]
+		res.Results[i].Index = i
+
+		err := product.Validate()
+		if err == nil && seen[strings.ToLower(product.Name)] {
+			err = errs.ValidationError{Message: fmt.Sprintf("product %s is duplicated in the request", product.Name)}
+		}
+		if err == nil && product.SKU != "" && seenSKUs[product.SKU] {
+			err = errs.ValidationError{Message: fmt.Sprintf("sku %s is duplicated in the request", product.SKU)}
+		}
+		if err == nil && product.Barcode != "" && seenBarcodes[product.Barcode] {
+			err = errs.ValidationError{Message: fmt.Sprintf("barcode %s is duplicated in the request", product.Barcode)}
+		}
+		if err == nil && ps.cfg.StrictProductType {
+			err = checkProductTypeIn(product.Type, productTypes)
+		}
+		if err != nil {
+			res.Results[i].Error = err.Error()
+			continue
+		}
+
+		seen[strings.ToLower(product.Name)] = true
+		seenSKUs[product.SKU] = true
+		seenBarcodes[product.Barcode] = true
+		valid = append(valid, i)
+	}
+
+	names := make([]string, 0, len(valid))
+	skus, barcodes := []string{}, []string{}
+	for _, i := range valid {
+		names = append(names, req.Products[i].Name)
+		if req.Products[i].SKU != "" {
+			skus = append(skus, req.Products[i].SKU)
+		}
+		if req.Products[i].Barcode != "" {
+			barcodes = append(barcodes, req.Products[i].Barcode)
+		}
+	}
+
+	existing, err := ps.db.ExistingProductNames(ctx, names)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	var existingSKUs, existingBarcodes []string
+	if len(skus) > 0 {
+		existingSKUs, err = ps.db.ExistingProductSKUs(ctx, skus)
+		if err != nil {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+	}
+	if len(barcodes) > 0 {
+		existingBarcodes, err = ps.db.ExistingProductBarcodes(ctx, barcodes)
+		if err != nil {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+	}
+
+	newProducts := []params.CreateProductRequest{}
+	created := []int{}
+	for _, i := range valid {
+		product := req.Products[i]
+		var err error
+		switch {
+		case slices.Contains(existing, strings.ToLower(product.Name)):
+			err = errs.AlreadyExistError{Message: fmt.Sprintf("product %s", product.Name)}
+		case product.SKU != "" && slices.Contains(existingSKUs, product.SKU):
+			err = errs.AlreadyExistError{Message: fmt.Sprintf("product with sku %s", product.SKU)}
+		case product.Barcode != "" && slices.Contains(existingBarcodes, product.Barcode):
+			err = errs.AlreadyExistError{Message: fmt.Sprintf("product with barcode %s", product.Barcode)}
+		}
+		if err != nil {
+			res.Results[i].Error = err.Error()
+			continue
+		}
+
+		newProducts = append(newProducts, product)
+		created = append(created, i)
+	}
+
+	res.Failed = len(req.Products) - len(created)
+	if len(created) == 0 || (res.Failed > 0 && !req.Partial) {
+		return res, nil
+	}
+
+	ids, err := ps.db.CreateProducts(ctx, newProducts)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range created {
+		res.Results[i].ID = ids[j]
+	}
+	res.Created = len(ids)
+
+	if err := ps.cache.FlushAllProducts(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	if err := ps.cache.FlushSuggestions(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush suggestions: %w", err)
+	}
+
+	return res, nil
+}
+
+func (ps *ProductService) UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*params.ProductResponse, error) {
+	exist, err := ps.db.ProductNameExists(ctx, req.Name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if exist {
+		return nil, errs.AlreadyExistError{
+			Message: fmt.Sprintf("product %s", req.Name),
+		}
+	}
+
+	if err := ps.checkProductCodes(ctx, params.CreateProductRequest(req), id); err != nil {
+		return nil, err
+	}
+
+	if err := ps.checkProductType(ctx, req.Type); err != nil {
+		return nil, err
+	}
+
+	product, err := ps.db.UpdateProduct(ctx, id, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("product %d", id),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.invalidateProduct(ctx, id); err != nil {
+		return nil, err
+	}
+
+	res := newProductResponse(*product)
+	return &res, nil
+}
+
+// checkProductCodes rejects a sku or barcode held by another product, soft
+// deleted products included since they can be restored.
+func (ps *ProductService) checkProductCodes(ctx context.Context, req params.CreateProductRequest, exceptID int) error {
+	if req.SKU != "" {
+		exist, err := ps.db.ProductSKUExists(ctx, req.SKU, exceptID)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		if exist {
+			return errs.AlreadyExistError{
+				Message: fmt.Sprintf("product with sku %s", req.SKU),
+			}
+		}
+	}
+
+	if req.Barcode != "" {
+		exist, err := ps.db.ProductBarcodeExists(ctx, req.Barcode, exceptID)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		if exist {
+			return errs.AlreadyExistError{
+				Message: fmt.Sprintf("product with barcode %s", req.Barcode),
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkProductType rejects unknown types in strict mode. Otherwise the
+// repository creates the type together with the product.
+func (ps *ProductService) checkProductType(ctx context.Context, productType string) error {
+	if !ps.cfg.StrictProductType {
+		return nil
+	}
+
+	exist, err := ps.db.ProductTypeExists(ctx, productType)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if exist {
+		return nil
+	}
+
+	// the valid types are only read to build the error
+	names, err := ps.productTypeNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkProductTypeIn(productType, names)
+}
+
+func (ps *ProductService) productTypeNames(ctx context.Context) ([]string, error) {
+	names, err := ps.db.ProductTypeNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return names, nil
+}
+
+func checkProductTypeIn(productType string, names []string) error {
+	if slices.Contains(names, productType) {
+		return nil
+	}
+
+	if len(names) == 0 {
+		return errs.ValidationError{Message: fmt.Sprintf("%s not valid type, no product type exists yet", productType)}
+	}
+
+	return errs.ValidationError{
+		Message: fmt.Sprintf("%s not valid type, valid types are %s", productType, strings.Join(names, ", ")),
+	}
+}
+
+func (ps *ProductService) PatchProduct(ctx context.Context, id int, req params.PatchProductRequest) (*params.ProductResponse, error) {
+	product, err := ps.db.GetProduct(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("product %d", id),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	updateReq := req.Apply(*product)
+	if err := updateReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ps.UpdateProduct(ctx, id, updateReq)
+}
+
+func (ps *ProductService) DeleteProduct(ctx context.Context, id int) error {
+	err := ps.db.DeleteProduct(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFoundError{
+			Message: fmt.Sprintf("product %d", id),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return ps.invalidateProduct(ctx, id)
+}
+
+func (ps *ProductService) RestoreProduct(ctx context.Context, id int) (*params.ProductResponse, error) {
+	product, err := ps.db.RestoreProduct(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("deleted product %d", id),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ps.invalidateProduct(ctx, id); err != nil {
+		return nil, err
+	}
+
+	res := newProductResponse(*product)
+	return &res, nil
+}
+
+func (ps *ProductService) SuggestProducts(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error) {
+	res, err := ps.cache.GetCachedSuggestions(ctx, req)
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("cache error: %w", err)
+	}
+
+	if err == nil {
+		return res, nil
+	}
+
+	products, err := ps.db.SuggestProductNames(ctx, req.Query, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	types, err := ps.db.SuggestProductTypeNames(ctx, req.Query, req.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res = &params.SuggestProductsResponse{
+		Products: products,
+		Types:    types,
+	}
+
+	if err := ps.cache.CacheSuggestions(ctx, req, *res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// invalidateProduct drops the cached entry of a single product together with
+// every cached list and suggestion, since the product may appear in any of them.
+func (ps *ProductService) invalidateProduct(ctx context.Context, id int) error {
+	if err := ps.cache.DeleteCachedProduct(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete cached product: %w", err)
+	}
+
+	if err := ps.cache.FlushAllProducts(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	if err := ps.cache.FlushSuggestions(ctx); err != nil {
+		return fmt.Errorf("failed to flush suggestions: %w", err)
+	}
+
+	return nil
+}
+
+// nextCursor encodes the sort values of the last product of the page.
+func nextCursor(sorts []params.Sort, product domain.Product) string {
+	values := make([]string, 0, len(sorts))
+	for _, sort := range sorts {
+		switch sort.Key {
+		case "id":
+			values = append(values, strconv.Itoa(product.ID))
+		case "name":
+			values = append(values, product.Name)
+		case "price":
+			values = append(values, strconv.Itoa(product.Price.Amount))
+		case "created_at":
+			values = append(values, product.CreatedAt.Format(time.RFC3339Nano))
+		case "relevance":
+			values = append(values, strconv.FormatFloat(product.Relevance, 'g', -1, 64))
+		}
+	}
+
+	return params.EncodeCursor(sorts, values)
+}
+
+func newProductResponse(product domain.Product) params.ProductResponse {
+	var variants []params.VariantResponse
+	for _, variant := range product.Variants {
+		variants = append(variants, newVariantResponse(variant))
+	}
+
+	return params.ProductResponse{
+		ID:         product.ID,
+		Name:       product.Name,
+		SKU:        product.SKU,
+		Barcode:    product.Barcode,
+		Price:      product.Price.Amount,
+		Currency:   product.Price.Currency,
+		FinalPrice: product.Price.Amount,
+		Type:       product.ProductType.Name,
+		Variants:   variants,
+		CreatedAt:  product.CreatedAt,
+		DeletedAt:  product.DeletedAt,
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+)
+
+// ExportProducts calls fn for every product matching req, without pagination.
+// The rows are streamed from the DB and never cached.
+func (ps *ProductService) ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(params.ProductResponse) error) error {
+	promotions, err := ps.activePromotions(ctx, req.AsOf)
+	if err != nil {
+		return err
+	}
+
+	return ps.db.ExportProducts(ctx, req, func(product domain.Product) error {
+		productRes := newProductResponse(product)
+		if req.SearchMode == params.SearchModeFuzzy {
+			productRes.Similarity = &product.Relevance
+		}
+		applyPromotions(&productRes, promotions)
+
+		return fn(productRes)
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// importBatchSize is the number of rows upserted in one transaction.
+const importBatchSize = 500
+
+// ImportProducts stream parses name, price, type and currency rows from src and upserts
+// them by name. Rows are committed in batches so the file is never loaded at
+// once, which also means the batches before a failing one stay imported, so the
+// caches are flushed after every committed batch. Rejected rows are reported with
+// their line number.
+func (ps *ProductService) ImportProducts(ctx context.Context, src io.Reader) (*params.ImportProductsResponse, error) {
+	res := &params.ImportProductsResponse{Rejected: []params.ImportRejectedRow{}}
+
+	var productTypes []string
+	if ps.cfg.StrictProductType {
+		var err error
+		productTypes, err = ps.productTypeNames(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	batch := make([]params.CreateProductRequest, 0, importBatchSize)
+	upsertBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		created, updated, err := ps.db.UpsertProducts(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		res.Created += created
+		res.Updated += updated
+		batch = batch[:0]
+
+		if created+updated == 0 {
+			return nil
+		}
+
+		return ps.flushImportedProducts(ctx)
+	}
+
+	reader := csv.NewReader(src)
+	reader.FieldsPerRecord = -1
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			res.Rejected = append(res.Rejected, params.ImportRejectedRow{
+				Line:   parseErr.StartLine,
+				Record: record,
+				Error:  parseErr.Err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if first && isImportHeader(record) {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		product, err := parseImportRecord(record)
+		if err == nil && ps.cfg.StrictProductType {
+			err = checkProductTypeIn(product.Type, productTypes)
+		}
+		if err != nil {
+			res.Rejected = append(res.Rejected, params.ImportRejectedRow{
+				Line:   line,
+				Record: record,
+				Error:  err.Error(),
+			})
+			continue
+		}
+
+		batch = append(batch, product)
+		if len(batch) == importBatchSize {
+			if err := upsertBatch(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := upsertBatch(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ps *ProductService) flushImportedProducts(ctx context.Context) error {
+	// updated rows change the price and type of cached products
+	if err := ps.cache.FlushProductDetails(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	if err := ps.cache.FlushAllProducts(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	if err := ps.cache.FlushSuggestions(ctx); err != nil {
+		return fmt.Errorf("failed to flush suggestions: %w", err)
+	}
+
+	return nil
+}
+
+// importColumns is the number of columns of an import file without the optional currency.
+var importColumns = len(params.ImportProductsHeader) - 1
+
+func isImportHeader(record []string) bool {
+	if len(record) != importColumns && len(record) != len(params.ImportProductsHeader) {
+		return false
+	}
+
+	for i, column := range record {
+		if !strings.EqualFold(strings.TrimSpace(column), params.ImportProductsHeader[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseImportRecord(record []string) (params.CreateProductRequest, error) {
+	if len(record) != importColumns && len(record) != len(params.ImportProductsHeader) {
+		return params.CreateProductRequest{}, errs.ValidationError{
+			Message: fmt.Sprintf("expected %d or %d columns, got %d", importColumns, len(params.ImportProductsHeader), len(record)),
+		}
+	}
+
+	price, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return params.CreateProductRequest{}, errs.ValidationError{Message: "not valid price"}
+	}
+
+	// validated as an update, a row without currency keeps the currency of an
+	// existing product and a new one gets the default currency when upserted
+	product := params.UpdateProductRequest{
+		Name:  strings.TrimSpace(record[0]),
+		Price: price,
+		Type:  strings.TrimSpace(record[2]),
+	}
+	if len(record) == len(params.ImportProductsHeader) {
+		product.Currency = record[3]
+	}
+
+	if err := product.Validate(); err != nil {
+		return params.CreateProductRequest{}, err
+	}
+
+	return params.CreateProductRequest(product), nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// GetProductPrices returns the price history of a product, every price change
+// is recorded by the DB.
+func (ps *ProductService) GetProductPrices(ctx context.Context, id int) (*params.ProductPricesResponse, error) {
+	// also returns the not found error of a missing or deleted product
+	if _, err := ps.getProduct(ctx, id); err != nil {
+		return nil, err
+	}
+
+	prices, err := ps.db.ListProductPrices(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := &params.ProductPricesResponse{
+		ProductID: id,
+		Prices:    make([]params.ProductPriceResponse, 0, len(prices)),
+	}
+	for i, price := range prices {
+		priceRes := params.ProductPriceResponse{
+			Price:         price.Price.Amount,
+			Currency:      price.Price.Currency,
+			EffectiveFrom: price.EffectiveFrom,
+		}
+		if i > 0 {
+			priceRes.EffectiveTo = &prices[i-1].EffectiveFrom
+		}
+		res.Prices = append(res.Prices, priceRes)
+	}
+
+	return res, nil
+}
+
+// scheduledPriceBatchSize is the number of due price changes applied in one transaction.
+const scheduledPriceBatchSize = 500
+
+func (ps *ProductService) ScheduleProductPrice(ctx context.Context, id int, req params.ScheduleProductPriceRequest) (*params.ScheduledPriceResponse, error) {
+	product, err := ps.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the price keeps its currency unless another one is scheduled
+	if req.Currency == "" {
+		req.Currency = product.Price.Currency
+	}
+
+	scheduled, err := ps.db.CreateScheduledPrice(ctx, id, req)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := newScheduledPriceResponse(*scheduled)
+	return &res, nil
+}
+
+// ListScheduledPrices returns the price changes of a product which are not applied yet.
+func (ps *ProductService) ListScheduledPrices(ctx context.Context, id int) ([]params.ScheduledPriceResponse, error) {
+	if _, err := ps.getProduct(ctx, id); err != nil {
+		return nil, err
+	}
+
+	scheduledPrices, err := ps.db.ListScheduledPrices(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := make([]params.ScheduledPriceResponse, 0, len(scheduledPrices))
+	for _, scheduled := range scheduledPrices {
+		res = append(res, newScheduledPriceResponse(scheduled))
+	}
+
+	return res, nil
+}
+
+func (ps *ProductService) CancelScheduledPrice(ctx context.Context, id, scheduledID int) error {
+	err := ps.db.DeleteScheduledPrice(ctx, id, scheduledID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFoundError{
+			Message: fmt.Sprintf("scheduled price %d", scheduledID),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyScheduledPrices applies every price change which is due and invalidates
+// the cache of the changed products. It returns the number of applied changes.
+func (ps *ProductService) ApplyScheduledPrices(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var applied int
+	for {
+		scheduledPrices, err := ps.db.ApplyDueScheduledPrices(ctx, now, scheduledPriceBatchSize)
+		if err != nil {
+			return applied, fmt.Errorf("db error: %w", err)
+		}
+
+		applied += len(scheduledPrices)
+
+		// every batch is committed on its own, so it is invalidated before the
+		// next one which may fail, even when the run is cancelled meanwhile
+		if err := ps.invalidateScheduledPrices(context.WithoutCancel(ctx), scheduledPrices); err != nil {
+			return applied, err
+		}
+
+		if len(scheduledPrices) < scheduledPriceBatchSize {
+			break
+		}
+	}
+
+	return applied, nil
+}
+
+func (ps *ProductService) invalidateScheduledPrices(ctx context.Context, scheduledPrices []domain.ScheduledPrice) error {
+	if len(scheduledPrices) == 0 {
+		return nil
+	}
+
+	var productIDs []int
+	for _, scheduled := range scheduledPrices {
+		if !slices.Contains(productIDs, scheduled.ProductID) {
+			productIDs = append(productIDs, scheduled.ProductID)
+		}
+	}
+
+	for _, id := range productIDs {
+		if err := ps.cache.DeleteCachedProduct(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete cached product: %w", err)
+		}
+	}
+
+	if err := ps.cache.FlushAllProducts(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	return nil
+}
+
+func newScheduledPriceResponse(scheduled domain.ScheduledPrice) params.ScheduledPriceResponse {
+	return params.ScheduledPriceResponse{
+		ID:          scheduled.ID,
+		ProductID:   scheduled.ProductID,
+		Price:       scheduled.Price.Amount,
+		Currency:    scheduled.Price.Currency,
+		EffectiveAt: scheduled.EffectiveAt,
+		CreatedAt:   scheduled.CreatedAt,
+	}
+}
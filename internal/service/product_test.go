@@ -2,13 +2,18 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/elangreza/lion-superindo/internal/domain"
 	"github.com/elangreza/lion-superindo/internal/params"
 	mockservice "github.com/elangreza/lion-superindo/mock/service"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -27,7 +32,7 @@ func (suite *TestProductServiceSuite) SetupSuite() {
 	suite.Ctrl = gomock.NewController(suite.T())
 	suite.MockDbRepo = mockservice.NewMockDbRepo(suite.Ctrl)
 	suite.MockCacheRepo = mockservice.NewMockCacheRepo(suite.Ctrl)
-	suite.Ps = NewProductService(suite.MockDbRepo, suite.MockCacheRepo)
+	suite.Ps = NewProductService(suite.MockDbRepo, suite.MockCacheRepo, ProductServiceConfig{})
 }
 
 func (suite *TestProductServiceSuite) TearDownSuite() {
@@ -115,7 +120,7 @@ func (suite *TestProductServiceSuite) TestProductService_GetProducts() {
 			{
 				ID:    1,
 				Name:  "milk",
-				Price: 20000,
+				Price: domain.Money{Amount: 20000, Currency: "IDR"},
 				ProductType: domain.ProductType{
 					Name:      "dairy",
 					CreatedAt: time.Now(),
@@ -126,6 +131,7 @@ func (suite *TestProductServiceSuite) TestProductService_GetProducts() {
 
 		suite.MockCacheRepo.EXPECT().GetCachedProducts(ctx, req).Return(ListProducts, nil)
 		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(1, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
 
 		got, err := suite.Ps.ListProducts(ctx, req)
 		suite.NoError(err)
@@ -150,7 +156,7 @@ func (suite *TestProductServiceSuite) TestProductService_GetProducts() {
 			{
 				ID:    1,
 				Name:  "milk",
-				Price: 20000,
+				Price: domain.Money{Amount: 20000, Currency: "IDR"},
 				ProductType: domain.ProductType{
 					Name:      "dairy",
 					CreatedAt: time.Now(),
@@ -164,6 +170,7 @@ func (suite *TestProductServiceSuite) TestProductService_GetProducts() {
 		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(0, redis.Nil)
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, req).Return(1, nil)
 		suite.MockCacheRepo.EXPECT().CacheProducts(ctx, req, 1, ListProducts).Return(nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
 
 		got, err := suite.Ps.ListProducts(ctx, req)
 		suite.NoError(err)
@@ -188,7 +195,7 @@ func (suite *TestProductServiceSuite) TestProductService_GetProducts() {
 			{
 				ID:    1,
 				Name:  "milk",
-				Price: 20000,
+				Price: domain.Money{Amount: 20000, Currency: "IDR"},
 				ProductType: domain.ProductType{
 					Name:      "dairy",
 					CreatedAt: time.Now(),
@@ -213,7 +220,8 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		req := params.CreateProductRequest{Name: "melon"}
 		ctx := context.Background()
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
-			Search: "melon",
+			Search:         "melon",
+			IncludeDeleted: true,
 		}).Return(0, errors.New("test"))
 
 		_, err := suite.Ps.CreateProduct(ctx, req)
@@ -223,7 +231,8 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		req := params.CreateProductRequest{Name: "melon"}
 		ctx := context.Background()
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
-			Search: "melon",
+			Search:         "melon",
+			IncludeDeleted: true,
 		}).Return(1, nil)
 
 		_, err := suite.Ps.CreateProduct(ctx, req)
@@ -234,7 +243,8 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		req := params.CreateProductRequest{Name: "melon"}
 		ctx := context.Background()
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
-			Search: "melon",
+			Search:         "melon",
+			IncludeDeleted: true,
 		}).Return(0, nil)
 		suite.MockDbRepo.EXPECT().CreateProduct(ctx, req).Return(0, errors.New("test"))
 
@@ -246,7 +256,8 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		req := params.CreateProductRequest{Name: "melon"}
 		ctx := context.Background()
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
-			Search: "melon",
+			Search:         "melon",
+			IncludeDeleted: true,
 		}).Return(0, nil)
 		suite.MockDbRepo.EXPECT().CreateProduct(ctx, req).Return(6, nil)
 		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(errors.New("test"))
@@ -259,10 +270,12 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		req := params.CreateProductRequest{Name: "melon"}
 		ctx := context.Background()
 		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
-			Search: "melon",
+			Search:         "melon",
+			IncludeDeleted: true,
 		}).Return(0, nil)
 		suite.MockDbRepo.EXPECT().CreateProduct(ctx, req).Return(6, nil)
 		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
 
 		res, err := suite.Ps.CreateProduct(ctx, req)
 		suite.NoError(err)
@@ -270,3 +283,964 @@ func (suite *TestProductServiceSuite) TestProductService_CreateProduct() {
 		suite.Equal(res.ID, 6)
 	})
 }
+
+func (suite *TestProductServiceSuite) TestProductService_CreateProduct_Codes() {
+	suite.Run("sku already exist", func() {
+		req := params.CreateProductRequest{Name: "melon", SKU: "BUAH-001"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "melon",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductSKUExists(ctx, "BUAH-001", 0).Return(true, nil)
+
+		_, err := suite.Ps.CreateProduct(ctx, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.EqualError(err, "product with sku BUAH-001 already exist")
+	})
+
+	suite.Run("error ProductSKUExists", func() {
+		req := params.CreateProductRequest{Name: "melon", SKU: "BUAH-001"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "melon",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductSKUExists(ctx, "BUAH-001", 0).Return(false, errors.New("test"))
+
+		_, err := suite.Ps.CreateProduct(ctx, req)
+		suite.EqualError(err, "db error: test")
+	})
+
+	suite.Run("barcode already exist", func() {
+		req := params.CreateProductRequest{Name: "melon", SKU: "BUAH-001", Barcode: "4006381333931"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "melon",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductSKUExists(ctx, "BUAH-001", 0).Return(false, nil)
+		suite.MockDbRepo.EXPECT().ProductBarcodeExists(ctx, "4006381333931", 0).Return(true, nil)
+
+		_, err := suite.Ps.CreateProduct(ctx, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.EqualError(err, "product with barcode 4006381333931 already exist")
+	})
+
+	suite.Run("success", func() {
+		req := params.CreateProductRequest{Name: "melon", Barcode: "4006381333931"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "melon",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductBarcodeExists(ctx, "4006381333931", 0).Return(false, nil)
+		suite.MockDbRepo.EXPECT().CreateProduct(ctx, req).Return(6, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := suite.Ps.CreateProduct(ctx, req)
+		suite.NoError(err)
+		suite.Equal(6, res.ID)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_CreateProduct_Strict_Product_Type() {
+	ps := NewProductService(suite.MockDbRepo, suite.MockCacheRepo, ProductServiceConfig{StrictProductType: true})
+	suite.Run("unknown type", func() {
+		req := params.CreateProductRequest{Name: "bayam", Type: "sayurab"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "bayam",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductTypeExists(ctx, "sayurab").Return(false, nil)
+		suite.MockDbRepo.EXPECT().ProductTypeNames(ctx).Return([]string{"buah", "sayuran"}, nil)
+
+		_, err := ps.CreateProduct(ctx, req)
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.EqualError(err, "validation error: sayurab not valid type, valid types are buah, sayuran")
+	})
+
+	suite.Run("known type", func() {
+		req := params.CreateProductRequest{Name: "bayam", Type: "sayuran"}
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().CountProducts(ctx, params.ListProductsQueryParams{
+			Search:         "bayam",
+			IncludeDeleted: true,
+		}).Return(0, nil)
+		suite.MockDbRepo.EXPECT().ProductTypeExists(ctx, "sayuran").Return(true, nil)
+		suite.MockDbRepo.EXPECT().CreateProduct(ctx, req).Return(7, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := ps.CreateProduct(ctx, req)
+		suite.NoError(err)
+		suite.Equal(7, res.ID)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_BulkCreateProducts() {
+	products := []params.CreateProductRequest{
+		{Name: "Melon", Price: 1000, Type: "Buah"},
+		{Name: "", Price: 1000, Type: "buah"},
+		{Name: "melon", Price: 2000, Type: "buah"},
+		{Name: "Apel", Price: 3000, Type: "buah"},
+		{Name: "Pisang", Price: 4000, Type: "buah"},
+	}
+	newProducts := []params.CreateProductRequest{
+		{Name: "Melon", Price: 1000, Type: "buah", Currency: "IDR"},
+		{Name: "Pisang", Price: 4000, Type: "buah", Currency: "IDR"},
+	}
+	wantErrors := []string{
+		"",
+		"validation error: name cannot be empty",
+		"validation error: product melon is duplicated in the request",
+		"product Apel already exist",
+		"",
+	}
+
+	suite.Run("nothing is created when any product is rejected", func() {
+		ctx := context.Background()
+		req := params.BulkCreateProductsRequest{Products: slices.Clone(products)}
+		suite.MockDbRepo.EXPECT().ExistingProductNames(ctx, []string{"Melon", "Apel", "Pisang"}).Return([]string{"apel"}, nil)
+
+		res, err := suite.Ps.BulkCreateProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal(0, res.Created)
+		suite.Equal(3, res.Failed)
+		for i, result := range res.Results {
+			suite.Equal(i, result.Index)
+			suite.Equal(0, result.ID)
+			suite.Equal(wantErrors[i], result.Error)
+		}
+	})
+
+	suite.Run("valid products are created in partial mode", func() {
+		ctx := context.Background()
+		req := params.BulkCreateProductsRequest{Products: slices.Clone(products), Partial: true}
+		suite.MockDbRepo.EXPECT().ExistingProductNames(ctx, []string{"Melon", "Apel", "Pisang"}).Return([]string{"apel"}, nil)
+		suite.MockDbRepo.EXPECT().CreateProducts(ctx, newProducts).Return([]int{10, 11}, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil).Times(1)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil).Times(1)
+
+		res, err := suite.Ps.BulkCreateProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal(2, res.Created)
+		suite.Equal(3, res.Failed)
+		suite.Equal(10, res.Results[0].ID)
+		suite.Equal(11, res.Results[4].ID)
+		for i, result := range res.Results {
+			suite.Equal(wantErrors[i], result.Error)
+		}
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_BulkCreateProducts_Codes() {
+	products := []params.CreateProductRequest{
+		{Name: "Melon", Price: 1000, Type: "buah", SKU: "buah-001", Barcode: "036000291452"},
+		{Name: "Apel", Price: 2000, Type: "buah", Barcode: "0036000291452"},
+		{Name: "Pisang", Price: 3000, Type: "buah", SKU: "BUAH-001"},
+		{Name: "Jeruk", Price: 4000, Type: "buah", SKU: "BUAH-004", Barcode: "4006381333931"},
+		{Name: "Mangga", Price: 5000, Type: "buah", Barcode: "4006381333932"},
+	}
+	wantErrors := []string{
+		"",
+		"validation error: barcode 0036000291452 is duplicated in the request",
+		"validation error: sku BUAH-001 is duplicated in the request",
+		"product with barcode 4006381333931 already exist",
+		"validation error: 4006381333932 not valid barcode, check digit should be 1",
+	}
+
+	ctx := context.Background()
+	req := params.BulkCreateProductsRequest{Products: products}
+	suite.MockDbRepo.EXPECT().ExistingProductNames(ctx, []string{"Melon", "Jeruk"}).Return([]string{}, nil)
+	suite.MockDbRepo.EXPECT().ExistingProductSKUs(ctx, []string{"BUAH-001", "BUAH-004"}).Return([]string{}, nil)
+	suite.MockDbRepo.EXPECT().ExistingProductBarcodes(ctx, []string{"0036000291452", "4006381333931"}).Return([]string{"4006381333931"}, nil)
+
+	res, err := suite.Ps.BulkCreateProducts(ctx, req)
+	suite.NoError(err)
+	suite.Equal(0, res.Created)
+	suite.Equal(4, res.Failed)
+	for i, result := range res.Results {
+		suite.Equal(wantErrors[i], result.Error)
+	}
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ImportProducts() {
+	suite.Run("rejected rows are reported with their line", func() {
+		ctx := context.Background()
+		src := strings.NewReader("name,price,type\n" +
+			"Melon,1000,Buah\n" +
+			",1000,buah\n" +
+			"Apel,murah,buah\n" +
+			"Pisang,2000\n" +
+			"Jeruk,300,buah,sgd\n" +
+			"Mangga,3000,buah,XYZ\n")
+
+		suite.MockDbRepo.EXPECT().UpsertProducts(ctx, []params.CreateProductRequest{
+			{Name: "Melon", Price: 1000, Type: "buah"},
+			{Name: "Jeruk", Price: 300, Type: "buah", Currency: "SGD"},
+		}).Return(1, 1, nil)
+		suite.MockCacheRepo.EXPECT().FlushProductDetails(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := suite.Ps.ImportProducts(ctx, src)
+		suite.NoError(err)
+		suite.Equal(1, res.Created)
+		suite.Equal(1, res.Updated)
+		suite.Equal([]params.ImportRejectedRow{
+			{Line: 3, Record: []string{"", "1000", "buah"}, Error: "validation error: name cannot be empty"},
+			{Line: 4, Record: []string{"Apel", "murah", "buah"}, Error: "validation error: not valid price"},
+			{Line: 5, Record: []string{"Pisang", "2000"}, Error: "validation error: expected 3 or 4 columns, got 2"},
+			{Line: 7, Record: []string{"Mangga", "3000", "buah", "XYZ"}, Error: "validation error: XYZ not valid currency"},
+		}, res.Rejected)
+
+		report := &strings.Builder{}
+		suite.NoError(res.WriteReport(report))
+		suite.Equal("line,name,price,type,currency,error\n"+
+			"3,,1000,buah,,validation error: name cannot be empty\n"+
+			"4,Apel,murah,buah,,validation error: not valid price\n"+
+			"5,Pisang,2000,,,\"validation error: expected 3 or 4 columns, got 2\"\n"+
+			"7,Mangga,3000,buah,XYZ,validation error: XYZ not valid currency\n", report.String())
+	})
+
+	suite.Run("rows are upserted in batches", func() {
+		ctx := context.Background()
+		src := &strings.Builder{}
+		for i := range importBatchSize + 1 {
+			fmt.Fprintf(src, "product %d,%d,buah\n", i, i)
+		}
+
+		suite.MockDbRepo.EXPECT().UpsertProducts(ctx, gomock.Len(importBatchSize)).Return(importBatchSize, 0, nil)
+		suite.MockDbRepo.EXPECT().UpsertProducts(ctx, gomock.Len(1)).Return(1, 0, nil)
+		suite.MockCacheRepo.EXPECT().FlushProductDetails(ctx).Return(nil).Times(2)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil).Times(2)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil).Times(2)
+
+		res, err := suite.Ps.ImportProducts(ctx, strings.NewReader(src.String()))
+		suite.NoError(err)
+		suite.Equal(importBatchSize+1, res.Created)
+		suite.Empty(res.Rejected)
+	})
+
+	suite.Run("committed batches are flushed when a later one fails", func() {
+		ctx := context.Background()
+		src := &strings.Builder{}
+		for i := range importBatchSize + 1 {
+			fmt.Fprintf(src, "product %d,%d,buah\n", i, i)
+		}
+
+		suite.MockDbRepo.EXPECT().UpsertProducts(ctx, gomock.Len(importBatchSize)).Return(importBatchSize, 0, nil)
+		suite.MockCacheRepo.EXPECT().FlushProductDetails(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+		suite.MockDbRepo.EXPECT().UpsertProducts(ctx, gomock.Len(1)).Return(0, 0, errors.New("test"))
+
+		res, err := suite.Ps.ImportProducts(ctx, strings.NewReader(src.String()))
+		suite.Error(err)
+		suite.Nil(res)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_GetProduct() {
+	product := &domain.Product{
+		ID:          1,
+		Name:        "milk",
+		Price:       domain.Money{Amount: 20000, Currency: "IDR"},
+		ProductType: domain.ProductType{Name: "dairy"},
+		CreatedAt:   time.Now(),
+	}
+
+	suite.Run("error GetCachedProduct", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, errors.New("test"))
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.Error(err)
+		suite.Nil(got)
+	})
+
+	suite.Run("product not found", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("err GetProduct", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, errors.New("test"))
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.Error(err)
+		suite.Nil(got)
+	})
+
+	suite.Run("err CacheProduct", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().CacheProduct(ctx, *product).Return(errors.New("test"))
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.Error(err)
+		suite.Nil(got)
+	})
+
+	suite.Run("success with using cached data", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.NoError(err)
+		suite.Equal(got.ID, 1)
+		suite.Equal(got.Type, "dairy")
+	})
+
+	suite.Run("success without using cached data", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().CacheProduct(ctx, *product).Return(nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.NoError(err)
+		suite.Equal(got.ID, 1)
+		suite.Equal(got.Name, "milk")
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_GetProductByBarcode() {
+	product := &domain.Product{
+		ID:          1,
+		Name:        "milk",
+		Barcode:     "4006381333931",
+		Price:       domain.Money{Amount: 20000, Currency: "IDR"},
+		ProductType: domain.ProductType{Name: "dairy"},
+		CreatedAt:   time.Now(),
+	}
+
+	suite.Run("success with using cached barcode", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedBarcode(ctx, "4006381333931").Return(1, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.GetProductByBarcode(ctx, "4006381333931")
+		suite.NoError(err)
+		suite.Equal(1, got.ID)
+		suite.Equal("4006381333931", got.Barcode)
+	})
+
+	suite.Run("success without using cached barcode", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedBarcode(ctx, "4006381333931").Return(0, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProductByBarcode(ctx, "4006381333931").Return(product, nil)
+		suite.MockCacheRepo.EXPECT().CacheBarcode(ctx, "4006381333931", 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().CacheProduct(ctx, *product).Return(nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.GetProductByBarcode(ctx, "4006381333931")
+		suite.NoError(err)
+		suite.Equal(1, got.ID)
+	})
+
+	suite.Run("cached barcode moved to another product", func() {
+		ctx := context.Background()
+		oldProduct := &domain.Product{ID: 2, Name: "old milk", Barcode: "0036000291452"}
+		suite.MockCacheRepo.EXPECT().GetCachedBarcode(ctx, "4006381333931").Return(2, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 2).Return(oldProduct, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedBarcode(ctx, "4006381333931").Return(nil)
+		suite.MockDbRepo.EXPECT().GetProductByBarcode(ctx, "4006381333931").Return(product, nil)
+		suite.MockCacheRepo.EXPECT().CacheBarcode(ctx, "4006381333931", 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().CacheProduct(ctx, *product).Return(nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.GetProductByBarcode(ctx, "4006381333931")
+		suite.NoError(err)
+		suite.Equal(1, got.ID)
+	})
+
+	suite.Run("cached product deleted", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedBarcode(ctx, "4006381333931").Return(1, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+		suite.MockCacheRepo.EXPECT().DeleteCachedBarcode(ctx, "4006381333931").Return(nil)
+		suite.MockDbRepo.EXPECT().GetProductByBarcode(ctx, "4006381333931").Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ps.GetProductByBarcode(ctx, "4006381333931")
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.EqualError(err, "product with barcode 4006381333931 not found")
+		suite.Nil(got)
+	})
+
+	suite.Run("error GetCachedBarcode", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedBarcode(ctx, "4006381333931").Return(0, errors.New("test"))
+
+		got, err := suite.Ps.GetProductByBarcode(ctx, "4006381333931")
+		suite.Error(err)
+		suite.Nil(got)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_GetProductPrices() {
+	suite.Run("error when product not found", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ps.GetProductPrices(ctx, 1)
+		suite.Error(err)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("each price ends when the next one starts", func() {
+		ctx := context.Background()
+		changedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+		createdAt := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 1500, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().ListProductPrices(ctx, 1).Return([]domain.ProductPrice{
+			{Price: domain.Money{Amount: 1500, Currency: "IDR"}, EffectiveFrom: changedAt},
+			{Price: domain.Money{Amount: 1000, Currency: "IDR"}, EffectiveFrom: createdAt},
+		}, nil)
+
+		got, err := suite.Ps.GetProductPrices(ctx, 1)
+		suite.NoError(err)
+		suite.Equal(&params.ProductPricesResponse{
+			ProductID: 1,
+			Prices: []params.ProductPriceResponse{
+				{Price: 1500, Currency: "IDR", EffectiveFrom: changedAt},
+				{Price: 1000, Currency: "IDR", EffectiveFrom: createdAt, EffectiveTo: &changedAt},
+			},
+		}, got)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ScheduleProductPrice() {
+	suite.Run("error when product not found", func() {
+		ctx := context.Background()
+		req := params.ScheduleProductPriceRequest{Price: 2500, EffectiveAt: time.Now().Add(time.Hour)}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ps.ScheduleProductPrice(ctx, 1, req)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		ctx := context.Background()
+		effectiveAt := time.Now().Add(time.Hour)
+		req := params.ScheduleProductPriceRequest{Price: 2500, EffectiveAt: effectiveAt}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1}, nil)
+		suite.MockDbRepo.EXPECT().CreateScheduledPrice(ctx, 1, req).Return(&domain.ScheduledPrice{
+			ID: 3, ProductID: 1, Price: domain.Money{Amount: 2500, Currency: "IDR"}, EffectiveAt: effectiveAt,
+		}, nil)
+
+		got, err := suite.Ps.ScheduleProductPrice(ctx, 1, req)
+		suite.NoError(err)
+		suite.Equal(3, got.ID)
+		suite.Equal(effectiveAt, got.EffectiveAt)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_CancelScheduledPrice() {
+	ctx := context.Background()
+	suite.MockDbRepo.EXPECT().DeleteScheduledPrice(ctx, 1, 3).Return(sql.ErrNoRows)
+
+	err := suite.Ps.CancelScheduledPrice(ctx, 1, 3)
+	suite.ErrorAs(err, &errs.NotFoundError{})
+	suite.Equal("scheduled price 3 not found", err.Error())
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ApplyScheduledPrices() {
+	suite.Run("nothing is due", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ApplyDueScheduledPrices(ctx, gomock.Any(), scheduledPriceBatchSize).Return(nil, nil)
+
+		applied, err := suite.Ps.ApplyScheduledPrices(ctx)
+		suite.NoError(err)
+		suite.Equal(0, applied)
+	})
+
+	suite.Run("due changes are applied in batches and the products are invalidated", func() {
+		ctx := context.Background()
+		fullBatch := make([]domain.ScheduledPrice, scheduledPriceBatchSize)
+		for i := range fullBatch {
+			fullBatch[i] = domain.ScheduledPrice{ID: i + 1, ProductID: 101}
+		}
+
+		gomock.InOrder(
+			suite.MockDbRepo.EXPECT().ApplyDueScheduledPrices(ctx, gomock.Any(), scheduledPriceBatchSize).Return(fullBatch, nil),
+			suite.MockDbRepo.EXPECT().ApplyDueScheduledPrices(ctx, gomock.Any(), scheduledPriceBatchSize).Return([]domain.ScheduledPrice{
+				{ID: scheduledPriceBatchSize + 1, ProductID: 102},
+			}, nil),
+		)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(gomock.Any(), 101).Return(nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(gomock.Any(), 102).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(gomock.Any()).Return(nil).Times(2)
+
+		applied, err := suite.Ps.ApplyScheduledPrices(ctx)
+		suite.NoError(err)
+		suite.Equal(scheduledPriceBatchSize+1, applied)
+	})
+
+	suite.Run("committed batches are invalidated when a later one fails", func() {
+		ctx := context.Background()
+		fullBatch := make([]domain.ScheduledPrice, scheduledPriceBatchSize)
+		for i := range fullBatch {
+			fullBatch[i] = domain.ScheduledPrice{ID: i + 1, ProductID: 101}
+		}
+
+		gomock.InOrder(
+			suite.MockDbRepo.EXPECT().ApplyDueScheduledPrices(ctx, gomock.Any(), scheduledPriceBatchSize).Return(fullBatch, nil),
+			suite.MockCacheRepo.EXPECT().DeleteCachedProduct(gomock.Any(), 101).Return(nil),
+			suite.MockCacheRepo.EXPECT().FlushAllProducts(gomock.Any()).Return(nil),
+			suite.MockDbRepo.EXPECT().ApplyDueScheduledPrices(ctx, gomock.Any(), scheduledPriceBatchSize).Return(nil, errors.New("test")),
+		)
+
+		applied, err := suite.Ps.ApplyScheduledPrices(ctx)
+		suite.Error(err)
+		suite.Equal(scheduledPriceBatchSize, applied)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_UpdateProduct() {
+	req := params.UpdateProductRequest{Name: "melon", Price: 1000, Type: "buah"}
+	product := &domain.Product{
+		ID:          1,
+		Name:        "melon",
+		Price:       domain.Money{Amount: 1000, Currency: "IDR"},
+		ProductType: domain.ProductType{Name: "buah"},
+	}
+
+	suite.Run("error ProductNameExists", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(false, errors.New("test"))
+
+		_, err := suite.Ps.UpdateProduct(ctx, 1, req)
+		suite.Error(err)
+	})
+
+	suite.Run("product name already used", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(true, nil)
+
+		_, err := suite.Ps.UpdateProduct(ctx, 1, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+	})
+
+	suite.Run("product not found", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(false, nil)
+		suite.MockDbRepo.EXPECT().UpdateProduct(ctx, 1, req).Return(nil, sql.ErrNoRows)
+
+		_, err := suite.Ps.UpdateProduct(ctx, 1, req)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("error when invalidating cache", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(false, nil)
+		suite.MockDbRepo.EXPECT().UpdateProduct(ctx, 1, req).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(errors.New("test"))
+
+		_, err := suite.Ps.UpdateProduct(ctx, 1, req)
+		suite.Error(err)
+	})
+
+	suite.Run("success", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(false, nil)
+		suite.MockDbRepo.EXPECT().UpdateProduct(ctx, 1, req).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := suite.Ps.UpdateProduct(ctx, 1, req)
+		suite.NoError(err)
+		suite.Equal(res.Name, "melon")
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_PatchProduct() {
+	product := &domain.Product{
+		ID:          1,
+		Name:        "melon",
+		Price:       domain.Money{Amount: 1000, Currency: "IDR"},
+		ProductType: domain.ProductType{Name: "buah"},
+	}
+
+	suite.Run("product not found", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		_, err := suite.Ps.PatchProduct(ctx, 1, params.PatchProductRequest{})
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("error when merged product is not valid", func() {
+		ctx := context.Background()
+		price := -1
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(product, nil)
+
+		_, err := suite.Ps.PatchProduct(ctx, 1, params.PatchProductRequest{Price: &price})
+		suite.ErrorAs(err, &errs.ValidationError{})
+	})
+
+	suite.Run("success", func() {
+		ctx := context.Background()
+		price := 2000
+		updated := *product
+		updated.Price.Amount = price
+		req := params.UpdateProductRequest{Name: "melon", Price: 2000, Type: "buah", Currency: "IDR"}
+		suite.MockDbRepo.EXPECT().GetProduct(ctx, 1).Return(product, nil)
+		suite.MockDbRepo.EXPECT().ProductNameExists(ctx, "melon", 1).Return(false, nil)
+		suite.MockDbRepo.EXPECT().UpdateProduct(ctx, 1, req).Return(&updated, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := suite.Ps.PatchProduct(ctx, 1, params.PatchProductRequest{Price: &price})
+		suite.NoError(err)
+		suite.Equal(res.Price, 2000)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_DeleteProduct() {
+	suite.Run("product not found", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().DeleteProduct(ctx, 1).Return(sql.ErrNoRows)
+
+		err := suite.Ps.DeleteProduct(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("error DeleteProduct", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().DeleteProduct(ctx, 1).Return(errors.New("test"))
+
+		err := suite.Ps.DeleteProduct(ctx, 1)
+		suite.Error(err)
+	})
+
+	suite.Run("success", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().DeleteProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		err := suite.Ps.DeleteProduct(ctx, 1)
+		suite.NoError(err)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_RestoreProduct() {
+	suite.Run("deleted product not found", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().RestoreProduct(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		_, err := suite.Ps.RestoreProduct(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("success", func() {
+		ctx := context.Background()
+		suite.MockDbRepo.EXPECT().RestoreProduct(ctx, 1).Return(&domain.Product{ID: 1, Name: "melon"}, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		res, err := suite.Ps.RestoreProduct(ctx, 1)
+		suite.NoError(err)
+		suite.Equal(res.ID, 1)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ListProducts_NextCursor() {
+	suite.Run("full page returns next cursor", func() {
+		req := params.ListProductsQueryParams{
+			Currency: "IDR",
+			PaginationParams: params.PaginationParams{
+				Sorts: []string{"price:desc"},
+				Limit: 1,
+			},
+		}
+		suite.NoError(req.Validate())
+		ctx := context.Background()
+
+		ListProducts := []domain.Product{{ID: 5, Name: "milk", Price: domain.Money{Amount: 1000, Currency: "IDR"}}}
+
+		suite.MockCacheRepo.EXPECT().GetCachedProducts(ctx, req).Return(ListProducts, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(2, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.ListProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal(params.EncodeCursor(req.GetSorts(), []string{"1000", "5"}), got.NextCursor)
+
+		next := req
+		next.Cursor = got.NextCursor
+		suite.NoError(next.Validate())
+		suite.Equal([]string{"1000", "5"}, next.GetCursorValues())
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ListProducts_Fuzzy_Similarity() {
+	suite.Run("similarity is returned in fuzzy search mode", func() {
+		req := params.ListProductsQueryParams{
+			Search:     "kangkong",
+			SearchMode: params.SearchModeFuzzy,
+			PaginationParams: params.PaginationParams{
+				Limit: 5,
+			},
+		}
+		suite.NoError(req.Validate())
+		ctx := context.Background()
+
+		ListProducts := []domain.Product{{ID: 101, Name: "Kangkung", Relevance: 0.5}}
+
+		suite.MockCacheRepo.EXPECT().GetCachedProducts(ctx, req).Return(ListProducts, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(1, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+
+		got, err := suite.Ps.ListProducts(ctx, req)
+		suite.NoError(err)
+		suite.Require().NotNil(got.Products[0].Similarity)
+		suite.Equal(0.5, *got.Products[0].Similarity)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ExportProducts() {
+	suite.Run("every product is mapped to a response", func() {
+		req := params.ListProductsQueryParams{Types: []string{"buah"}}
+		suite.NoError(req.Validate())
+		ctx := context.Background()
+
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{}, nil)
+		suite.MockDbRepo.EXPECT().ExportProducts(ctx, req, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ params.ListProductsQueryParams, fn func(domain.Product) error) error {
+				for _, product := range []domain.Product{
+					{ID: 1, Name: "Melon", Price: domain.Money{Amount: 1000, Currency: "IDR"}, ProductType: domain.ProductType{Name: "buah"}},
+					{ID: 2, Name: "Apel", Price: domain.Money{Amount: 2000, Currency: "IDR"}, ProductType: domain.ProductType{Name: "buah"}},
+				} {
+					if err := fn(product); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		var got []params.ProductResponse
+		err := suite.Ps.ExportProducts(ctx, req, func(product params.ProductResponse) error {
+			got = append(got, product)
+			return nil
+		})
+		suite.NoError(err)
+		suite.Equal([]params.ProductResponse{
+			{ID: 1, Name: "Melon", Price: 1000, Currency: "IDR", FinalPrice: 1000, Type: "buah"},
+			{ID: 2, Name: "Apel", Price: 2000, Currency: "IDR", FinalPrice: 2000, Type: "buah"},
+		}, got)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ListProducts_Type_Facets() {
+	req := params.ListProductsQueryParams{
+		Types:  []string{"snack"},
+		Facets: []string{"type"},
+		PaginationParams: params.PaginationParams{
+			Limit: 5,
+		},
+	}
+	suite.NoError(req.Validate())
+	ctx := context.Background()
+
+	suite.Run("facets are counted even when no product matches the type", func() {
+		suite.MockCacheRepo.EXPECT().GetCachedProducts(ctx, req).Return([]domain.Product{}, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(0, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductTypeFacets(ctx, req).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().CountProductsByType(ctx, req).Return(map[string]int{"buah": 2, "sayuran": 5, "protein": 2}, nil)
+		suite.MockCacheRepo.EXPECT().CacheProductTypeFacets(ctx, req, map[string]int{"buah": 2, "sayuran": 5, "protein": 2}).Return(nil)
+
+		got, err := suite.Ps.ListProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal([]params.FacetCount{
+			{Value: "sayuran", Count: 5},
+			{Value: "buah", Count: 2},
+			{Value: "protein", Count: 2},
+		}, got.Facets[params.FacetType])
+	})
+
+	suite.Run("cached facets are used", func() {
+		suite.MockCacheRepo.EXPECT().GetCachedProducts(ctx, req).Return([]domain.Product{}, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductCount(ctx, req).Return(0, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedProductTypeFacets(ctx, req).Return(map[string]int{"buah": 2}, nil)
+
+		got, err := suite.Ps.ListProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal([]params.FacetCount{{Value: "buah", Count: 2}}, got.Facets[params.FacetType])
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_SuggestProducts() {
+	req := params.SuggestProductsQueryParams{Query: "sa", Limit: 5}
+	suggestions := &params.SuggestProductsResponse{
+		Products: []string{"Sawi"},
+		Types:    []string{"sayuran"},
+	}
+
+	suite.Run("error GetCachedSuggestions", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedSuggestions(ctx, req).Return(nil, errors.New("test"))
+
+		got, err := suite.Ps.SuggestProducts(ctx, req)
+		suite.Error(err)
+		suite.Nil(got)
+	})
+
+	suite.Run("success with using cached data", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedSuggestions(ctx, req).Return(suggestions, nil)
+
+		got, err := suite.Ps.SuggestProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal(suggestions, got)
+	})
+
+	suite.Run("err SuggestProductTypeNames", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedSuggestions(ctx, req).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().SuggestProductNames(ctx, "sa", 5).Return([]string{"Sawi"}, nil)
+		suite.MockDbRepo.EXPECT().SuggestProductTypeNames(ctx, "sa", 5).Return(nil, errors.New("test"))
+
+		got, err := suite.Ps.SuggestProducts(ctx, req)
+		suite.Error(err)
+		suite.Nil(got)
+	})
+
+	suite.Run("success without using cached data", func() {
+		ctx := context.Background()
+		suite.MockCacheRepo.EXPECT().GetCachedSuggestions(ctx, req).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().SuggestProductNames(ctx, "sa", 5).Return([]string{"Sawi"}, nil)
+		suite.MockDbRepo.EXPECT().SuggestProductTypeNames(ctx, "sa", 5).Return([]string{"sayuran"}, nil)
+		suite.MockCacheRepo.EXPECT().CacheSuggestions(ctx, req, *suggestions).Return(nil)
+
+		got, err := suite.Ps.SuggestProducts(ctx, req)
+		suite.NoError(err)
+		suite.Equal(suggestions, got)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_ListVariants() {
+	ctx := context.Background()
+	suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{
+		ID:    1,
+		Name:  "Donat",
+		Price: domain.Money{Amount: 2000, Currency: "IDR"},
+		Variants: []domain.Variant{
+			{ID: 2, ProductID: 1, Attributes: map[string]string{"flavor": "chocolate", "size": "6"}, Price: domain.Money{Amount: 12000, Currency: "IDR"}},
+		},
+	}, nil)
+	suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return([]domain.Promotion{
+		{
+			ID: 5, Name: "Donat day", DiscountType: params.DiscountPercentage, DiscountValue: 10, ProductID: 1,
+			StartsAt: time.Now().Add(-time.Hour), EndsAt: time.Now().Add(time.Hour),
+		},
+	}, nil)
+
+	got, err := suite.Ps.ListVariants(ctx, 1)
+	suite.NoError(err)
+	suite.Len(got, 1)
+	suite.Equal(12000, got[0].Price)
+	suite.Equal(10800, got[0].FinalPrice)
+	suite.Equal(5, got[0].Promotion.ID)
+}
+
+func (suite *TestProductServiceSuite) TestProductService_CreateVariant() {
+	suite.Run("error when attributes exist", func() {
+		ctx := context.Background()
+		req := params.CreateVariantRequest{Attributes: map[string]string{"size": "6", "flavor": "chocolate"}, Price: 12000}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 2000, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().VariantAttributesExist(ctx, 1, req.Attributes, 0).Return(true, nil)
+
+		got, err := suite.Ps.CreateVariant(ctx, 1, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.EqualError(err, "variant flavor:chocolate,size:6 of product 1 already exist")
+		suite.Nil(got)
+	})
+
+	suite.Run("error VariantAttributesExist", func() {
+		ctx := context.Background()
+		req := params.CreateVariantRequest{Attributes: map[string]string{"flavor": "cheese"}, Price: 12000}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 2000, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().VariantAttributesExist(ctx, 1, req.Attributes, 0).Return(false, errors.New("test"))
+
+		got, err := suite.Ps.CreateVariant(ctx, 1, req)
+		suite.EqualError(err, "db error: test")
+		suite.Nil(got)
+	})
+
+	suite.Run("error VariantSKUExists", func() {
+		ctx := context.Background()
+		req := params.CreateVariantRequest{Attributes: map[string]string{"flavor": "cheese"}, Price: 12000, SKU: "DONAT-CHE"}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 2000, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().VariantAttributesExist(ctx, 1, req.Attributes, 0).Return(false, nil)
+		suite.MockDbRepo.EXPECT().VariantSKUExists(ctx, "DONAT-CHE", 0).Return(false, errors.New("test"))
+
+		got, err := suite.Ps.CreateVariant(ctx, 1, req)
+		suite.EqualError(err, "db error: test")
+		suite.Nil(got)
+	})
+
+	suite.Run("error when sku exists", func() {
+		ctx := context.Background()
+		req := params.CreateVariantRequest{Attributes: map[string]string{"flavor": "cheese"}, Price: 12000, SKU: "DONAT-CHE"}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 2000, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().VariantAttributesExist(ctx, 1, req.Attributes, 0).Return(false, nil)
+		suite.MockDbRepo.EXPECT().VariantSKUExists(ctx, "DONAT-CHE", 0).Return(true, nil)
+
+		got, err := suite.Ps.CreateVariant(ctx, 1, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success with the product currency", func() {
+		ctx := context.Background()
+		req := params.CreateVariantRequest{Attributes: map[string]string{"flavor": "cheese"}, Price: 12000}
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(&domain.Product{ID: 1, Price: domain.Money{Amount: 2000, Currency: "IDR"}}, nil)
+		suite.MockDbRepo.EXPECT().VariantAttributesExist(ctx, 1, req.Attributes, 0).Return(false, nil)
+		withCurrency := req
+		withCurrency.Currency = "IDR"
+		suite.MockDbRepo.EXPECT().CreateVariant(ctx, 1, withCurrency).Return(&domain.Variant{
+			ID: 3, ProductID: 1, Attributes: req.Attributes, Price: domain.Money{Amount: 12000, Currency: "IDR"},
+		}, nil)
+		suite.MockCacheRepo.EXPECT().DeleteCachedProduct(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		got, err := suite.Ps.CreateVariant(ctx, 1, req)
+		suite.NoError(err)
+		suite.Equal(3, got.ID)
+		suite.Equal("IDR", got.Currency)
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_DeleteVariant() {
+	ctx := context.Background()
+	suite.MockDbRepo.EXPECT().DeleteVariant(ctx, 1, 3).Return(sql.ErrNoRows)
+
+	err := suite.Ps.DeleteVariant(ctx, 1, 3)
+	suite.ErrorAs(err, &errs.NotFoundError{})
+}
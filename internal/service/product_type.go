@@ -0,0 +1,288 @@
+package service
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/service/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	ProductTypeRepo interface {
+		ListProductTypes(ctx context.Context) ([]domain.ProductType, error)
+		GetProductType(ctx context.Context, name string) (*domain.ProductType, error)
+		CreateProductType(ctx context.Context, name, parent string) (*domain.ProductType, error)
+		RenameProductType(ctx context.Context, name, newName string) error
+		MoveProductType(ctx context.Context, name, parent string) error
+		CountProductsOfType(ctx context.Context, name string) (int, error)
+		CountChildProductTypes(ctx context.Context, name string) (int, error)
+		DeleteProductType(ctx context.Context, name string) error
+	}
+
+	ProductTypeService struct {
+		db    ProductTypeRepo
+		cache CacheRepo
+	}
+)
+
+func NewProductTypeService(repo ProductTypeRepo, cache CacheRepo) *ProductTypeService {
+	return &ProductTypeService{
+		db:    repo,
+		cache: cache,
+	}
+}
+
+func (pts *ProductTypeService) ListProductTypes(ctx context.Context) ([]params.ProductTypeResponse, error) {
+	productTypes, err := pts.db.ListProductTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return newProductTypeResponses(productTypes), nil
+}
+
+func (pts *ProductTypeService) CreateProductType(ctx context.Context, req params.CreateProductTypeRequest) (*params.ProductTypeResponse, error) {
+	if err := pts.checkProductTypeName(ctx, req.Name); err != nil {
+		return nil, err
+	}
+
+	if req.Parent != "" {
+		if err := pts.checkParent(ctx, req.Parent); err != nil {
+			return nil, err
+		}
+	}
+
+	productType, err := pts.db.CreateProductType(ctx, req.Name, req.Parent)
+	if errors.Is(err, domain.ErrProductTypeExists) {
+		return nil, errs.AlreadyExistError{
+			Message: fmt.Sprintf("product type %s", req.Name),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pts.cache.FlushSuggestions(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	// promotions scoped to an ancestor type cache its descendants
+	if req.Parent != "" {
+		if err := pts.cache.FlushPromotions(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+	}
+
+	res := newProductTypeResponses([]domain.ProductType{*productType})[0]
+	return &res, nil
+}
+
+func (pts *ProductTypeService) RenameProductType(ctx context.Context, name string, req params.RenameProductTypeRequest) (*params.ProductTypeResponse, error) {
+	if name != req.Name {
+		if err := pts.checkProductTypeName(ctx, req.Name); err != nil {
+			return nil, err
+		}
+
+		err := pts.db.RenameProductType(ctx, name, req.Name)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.NotFoundError{
+				Message: fmt.Sprintf("product type %s", name),
+			}
+		}
+		if errors.Is(err, domain.ErrProductTypeExists) {
+			return nil, errs.AlreadyExistError{
+				Message: fmt.Sprintf("product type %s", req.Name),
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// the type is part of every cached product, list and suggestion
+		if err := pts.cache.FlushProductDetails(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+
+		if err := pts.cache.FlushAllProducts(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+
+		if err := pts.cache.FlushSuggestions(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+
+		if err := pts.cache.FlushPromotions(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+	}
+
+	productTypes, err := pts.ListProductTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, productType := range productTypes {
+		if productType.Name == req.Name {
+			return &productType, nil
+		}
+	}
+
+	return nil, errs.NotFoundError{
+		Message: fmt.Sprintf("product type %s", name),
+	}
+}
+
+// MoveProductType leaves the existence and cycle checks to the repository, which
+// runs them in the transaction of the move.
+func (pts *ProductTypeService) MoveProductType(ctx context.Context, name string, req params.MoveProductTypeRequest) (*params.ProductTypeResponse, error) {
+	err := pts.db.MoveProductType(ctx, name, req.Parent)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("product type %s", name),
+		}
+	}
+	if errors.Is(err, domain.ErrParentNotFound) {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("%s not valid parent", req.Parent)}
+	}
+	if errors.Is(err, domain.ErrProductTypeCycle) {
+		return nil, errs.ValidationError{
+			Message: fmt.Sprintf("%s cannot be moved under itself or its descendant %s", name, req.Parent),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// lists filtered with include_subtypes depend on the tree
+	if err := pts.cache.FlushAllProducts(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	// so are promotions scoped to an ancestor type
+	if err := pts.cache.FlushPromotions(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	productTypes, err := pts.ListProductTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, productType := range productTypes {
+		if productType.Name == name {
+			return &productType, nil
+		}
+	}
+
+	return nil, errs.NotFoundError{
+		Message: fmt.Sprintf("product type %s", name),
+	}
+}
+
+func (pts *ProductTypeService) DeleteProductType(ctx context.Context, name string) error {
+	children, err := pts.db.CountChildProductTypes(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if children > 0 {
+		return errs.ConflictError{
+			Message: fmt.Sprintf("product type %s still has %d sub types", name, children),
+		}
+	}
+
+	// soft deleted products still reference their type
+	total, err := pts.db.CountProductsOfType(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return errs.ConflictError{
+			Message: fmt.Sprintf("product type %s is still used by %d products", name, total),
+		}
+	}
+
+	err = pts.db.DeleteProductType(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFoundError{
+			Message: fmt.Sprintf("product type %s", name),
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := pts.cache.FlushSuggestions(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	// promotions of the type are deleted with it
+	if err := pts.cache.FlushPromotions(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	return nil
+}
+
+// checkProductTypeName returns AlreadyExistError when name is taken by another type.
+func (pts *ProductTypeService) checkProductTypeName(ctx context.Context, name string) error {
+	_, err := pts.db.GetProductType(ctx, name)
+	if err == nil {
+		return errs.AlreadyExistError{
+			Message: fmt.Sprintf("product type %s", name),
+		}
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return nil
+}
+
+func (pts *ProductTypeService) checkParent(ctx context.Context, parent string) error {
+	_, err := pts.db.GetProductType(ctx, parent)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.ValidationError{Message: fmt.Sprintf("%s not valid parent", parent)}
+	}
+
+	return err
+}
+
+// newProductTypeResponses maps the types and sums the products of every subtree.
+func newProductTypeResponses(productTypes []domain.ProductType) []params.ProductTypeResponse {
+	children := make(map[string][]string)
+	totals := make(map[string]int, len(productTypes))
+	for _, productType := range productTypes {
+		children[productType.ParentName] = append(children[productType.ParentName], productType.Name)
+		totals[productType.Name] = productType.TotalProducts
+	}
+
+	var subtree func(name string) int
+	subtree = func(name string) int {
+		total := totals[name]
+		for _, child := range children[name] {
+			total += subtree(child)
+		}
+		return total
+	}
+
+	res := make([]params.ProductTypeResponse, 0, len(productTypes))
+	for _, productType := range productTypes {
+		res = append(res, params.ProductTypeResponse{
+			Name:            productType.Name,
+			Parent:          productType.ParentName,
+			TotalProducts:   productType.TotalProducts,
+			SubtreeProducts: subtree(productType.Name),
+			CreatedAt:       productType.CreatedAt,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockservice "github.com/elangreza/lion-superindo/mock/service"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestProductTypeServiceSuite struct {
+	suite.Suite
+
+	MockProductTypeRepo *mockservice.MockProductTypeRepo
+	MockCacheRepo       *mockservice.MockCacheRepo
+	Pts                 *ProductTypeService
+	Ctrl                *gomock.Controller
+}
+
+func (suite *TestProductTypeServiceSuite) SetupSuite() {
+	suite.Ctrl = gomock.NewController(suite.T())
+	suite.MockProductTypeRepo = mockservice.NewMockProductTypeRepo(suite.Ctrl)
+	suite.MockCacheRepo = mockservice.NewMockCacheRepo(suite.Ctrl)
+	suite.Pts = NewProductTypeService(suite.MockProductTypeRepo, suite.MockCacheRepo)
+}
+
+func (suite *TestProductTypeServiceSuite) TearDownSuite() {
+	suite.Ctrl.Finish()
+}
+
+func TestProductTypeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TestProductTypeServiceSuite))
+}
+
+func (suite *TestProductTypeServiceSuite) TestProductTypeService_ListProductTypes() {
+	ctx := context.Background()
+	suite.MockProductTypeRepo.EXPECT().ListProductTypes(ctx).Return([]domain.ProductType{
+		{Name: "apel", ParentName: "buah impor", TotalProducts: 3},
+		{Name: "buah", TotalProducts: 2},
+		{Name: "buah impor", ParentName: "buah", TotalProducts: 1},
+		{Name: "sayuran", TotalProducts: 0},
+	}, nil)
+
+	got, err := suite.Pts.ListProductTypes(ctx)
+	suite.NoError(err)
+	suite.Equal([]params.ProductTypeResponse{
+		{Name: "apel", Parent: "buah impor", TotalProducts: 3, SubtreeProducts: 3},
+		{Name: "buah", TotalProducts: 2, SubtreeProducts: 6},
+		{Name: "buah impor", Parent: "buah", TotalProducts: 1, SubtreeProducts: 4},
+		{Name: "sayuran", TotalProducts: 0, SubtreeProducts: 0},
+	}, got)
+}
+
+func (suite *TestProductTypeServiceSuite) TestProductTypeService_CreateProductType() {
+	ctx := context.Background()
+	req := params.CreateProductTypeRequest{Name: "minuman"}
+
+	suite.Run("already exist", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "minuman").Return(&domain.ProductType{Name: "minuman"}, nil)
+
+		_, err := suite.Pts.CreateProductType(ctx, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+	})
+
+	suite.Run("parent not found", func() {
+		req := params.CreateProductTypeRequest{Name: "apel", Parent: "buah impor"}
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "apel").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "buah impor").Return(nil, sql.ErrNoRows)
+
+		_, err := suite.Pts.CreateProductType(ctx, req)
+		suite.EqualError(err, "validation error: buah impor not valid parent")
+	})
+
+	suite.Run("created concurrently", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "minuman").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().CreateProductType(ctx, "minuman", "").Return(nil, domain.ErrProductTypeExists)
+
+		_, err := suite.Pts.CreateProductType(ctx, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.EqualError(err, "product type minuman already exist")
+	})
+
+	suite.Run("success", func() {
+		now := time.Now()
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "minuman").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().CreateProductType(ctx, "minuman", "").Return(&domain.ProductType{Name: "minuman", CreatedAt: now}, nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+
+		got, err := suite.Pts.CreateProductType(ctx, req)
+		suite.NoError(err)
+		suite.Equal(&params.ProductTypeResponse{Name: "minuman", CreatedAt: now}, got)
+	})
+
+	suite.Run("subtype flushes the promotions of its ancestors", func() {
+		req := params.CreateProductTypeRequest{Name: "apel", Parent: "buah"}
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "apel").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "buah").Return(&domain.ProductType{Name: "buah"}, nil)
+		suite.MockProductTypeRepo.EXPECT().CreateProductType(ctx, "apel", "buah").Return(&domain.ProductType{Name: "apel", ParentName: "buah"}, nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+
+		got, err := suite.Pts.CreateProductType(ctx, req)
+		suite.NoError(err)
+		suite.Equal("buah", got.Parent)
+	})
+}
+
+func (suite *TestProductTypeServiceSuite) TestProductTypeService_RenameProductType() {
+	ctx := context.Background()
+	req := params.RenameProductTypeRequest{Name: "sayuran"}
+
+	suite.Run("new name already exist", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "sayuran").Return(&domain.ProductType{Name: "sayuran"}, nil)
+
+		_, err := suite.Pts.RenameProductType(ctx, "sayurab", req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+	})
+
+	suite.Run("not found", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "sayuran").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().RenameProductType(ctx, "sayurab", "sayuran").Return(sql.ErrNoRows)
+
+		_, err := suite.Pts.RenameProductType(ctx, "sayurab", req)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("renamed concurrently", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "sayuran").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().RenameProductType(ctx, "sayurab", "sayuran").Return(domain.ErrProductTypeExists)
+
+		_, err := suite.Pts.RenameProductType(ctx, "sayurab", req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+	})
+
+	suite.Run("success", func() {
+		suite.MockProductTypeRepo.EXPECT().GetProductType(ctx, "sayuran").Return(nil, sql.ErrNoRows)
+		suite.MockProductTypeRepo.EXPECT().RenameProductType(ctx, "sayurab", "sayuran").Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushProductDetails(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+		suite.MockProductTypeRepo.EXPECT().ListProductTypes(ctx).Return([]domain.ProductType{{Name: "sayuran", TotalProducts: 3}}, nil)
+
+		got, err := suite.Pts.RenameProductType(ctx, "sayurab", req)
+		suite.NoError(err)
+		suite.Equal("sayuran", got.Name)
+		suite.Equal(3, got.TotalProducts)
+	})
+}
+
+func (suite *TestProductTypeServiceSuite) TestProductTypeService_MoveProductType() {
+	ctx := context.Background()
+	productTypes := []domain.ProductType{
+		{Name: "apel", ParentName: "buah impor", TotalProducts: 3},
+		{Name: "buah", TotalProducts: 2},
+		{Name: "buah impor", ParentName: "buah", TotalProducts: 1},
+	}
+
+	suite.Run("not found", func() {
+		suite.MockProductTypeRepo.EXPECT().MoveProductType(ctx, "sayuran", "").Return(sql.ErrNoRows)
+
+		_, err := suite.Pts.MoveProductType(ctx, "sayuran", params.MoveProductTypeRequest{})
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("under its own descendant", func() {
+		suite.MockProductTypeRepo.EXPECT().MoveProductType(ctx, "buah", "apel").Return(domain.ErrProductTypeCycle)
+
+		_, err := suite.Pts.MoveProductType(ctx, "buah", params.MoveProductTypeRequest{Parent: "apel"})
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.EqualError(err, "validation error: buah cannot be moved under itself or its descendant apel")
+	})
+
+	suite.Run("parent not found", func() {
+		suite.MockProductTypeRepo.EXPECT().MoveProductType(ctx, "buah impor", "sayur").Return(domain.ErrParentNotFound)
+
+		_, err := suite.Pts.MoveProductType(ctx, "buah impor", params.MoveProductTypeRequest{Parent: "sayur"})
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.EqualError(err, "validation error: sayur not valid parent")
+	})
+
+	suite.Run("success", func() {
+		suite.MockProductTypeRepo.EXPECT().MoveProductType(ctx, "buah impor", "").Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+		moved := slices.Clone(productTypes)
+		moved[2].ParentName = ""
+		suite.MockProductTypeRepo.EXPECT().ListProductTypes(ctx).Return(moved, nil)
+
+		got, err := suite.Pts.MoveProductType(ctx, "buah impor", params.MoveProductTypeRequest{})
+		suite.NoError(err)
+		suite.Equal("", got.Parent)
+		suite.Equal(4, got.SubtreeProducts)
+	})
+}
+
+func (suite *TestProductTypeServiceSuite) TestProductTypeService_DeleteProductType() {
+	ctx := context.Background()
+
+	suite.Run("still has sub types", func() {
+		suite.MockProductTypeRepo.EXPECT().CountChildProductTypes(ctx, "buah").Return(1, nil)
+
+		err := suite.Pts.DeleteProductType(ctx, "buah")
+		suite.ErrorAs(err, &errs.ConflictError{})
+		suite.EqualError(err, "product type buah still has 1 sub types")
+	})
+
+	suite.Run("still used by products", func() {
+		suite.MockProductTypeRepo.EXPECT().CountChildProductTypes(ctx, "buah").Return(0, nil)
+		suite.MockProductTypeRepo.EXPECT().CountProductsOfType(ctx, "buah").Return(2, nil)
+
+		err := suite.Pts.DeleteProductType(ctx, "buah")
+		suite.ErrorAs(err, &errs.ConflictError{})
+		suite.EqualError(err, "product type buah is still used by 2 products")
+	})
+
+	suite.Run("not found", func() {
+		suite.MockProductTypeRepo.EXPECT().CountChildProductTypes(ctx, "buah").Return(0, nil)
+		suite.MockProductTypeRepo.EXPECT().CountProductsOfType(ctx, "buah").Return(0, nil)
+		suite.MockProductTypeRepo.EXPECT().DeleteProductType(ctx, "buah").Return(sql.ErrNoRows)
+
+		err := suite.Pts.DeleteProductType(ctx, "buah")
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("success", func() {
+		suite.MockProductTypeRepo.EXPECT().CountChildProductTypes(ctx, "buah").Return(0, nil)
+		suite.MockProductTypeRepo.EXPECT().CountProductsOfType(ctx, "buah").Return(0, nil)
+		suite.MockProductTypeRepo.EXPECT().DeleteProductType(ctx, "buah").Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushSuggestions(ctx).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+
+		suite.NoError(suite.Pts.DeleteProductType(ctx, "buah"))
+	})
+}
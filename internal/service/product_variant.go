@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// ListVariants returns the variants of a product with their final prices.
+func (ps *ProductService) ListVariants(ctx context.Context, productID int) ([]params.VariantResponse, error) {
+	product, err := ps.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Variants == nil {
+		return []params.VariantResponse{}, nil
+	}
+
+	return product.Variants, nil
+}
+
+func (ps *ProductService) CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*params.VariantResponse, error) {
+	product, err := ps.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// variants are sold in the currency of the product unless told otherwise
+	if req.Currency == "" {
+		req.Currency = product.Price.Currency
+	}
+
+	if err := ps.checkVariant(ctx, productID, params.UpdateVariantRequest(req), 0); err != nil {
+		return nil, err
+	}
+
+	variant, err := ps.db.CreateVariant(ctx, productID, req)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if err := ps.invalidateProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	res := newVariantResponse(*variant)
+	return &res, nil
+}
+
+func (ps *ProductService) UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*params.VariantResponse, error) {
+	product, err := ps.getProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Currency == "" {
+		req.Currency = product.Price.Currency
+	}
+
+	if err := ps.checkVariant(ctx, productID, req, id); err != nil {
+		return nil, err
+	}
+
+	variant, err := ps.db.UpdateVariant(ctx, productID, id, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("variant %d of product %d", id, productID),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if err := ps.invalidateProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	res := newVariantResponse(*variant)
+	return &res, nil
+}
+
+func (ps *ProductService) DeleteVariant(ctx context.Context, productID, id int) error {
+	err := ps.db.DeleteVariant(ctx, productID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFoundError{
+			Message: fmt.Sprintf("variant %d of product %d", id, productID),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	return ps.invalidateProduct(ctx, productID)
+}
+
+// checkVariant rejects the attributes of another variant of the product, and
+// a sku held by another variant or by a product.
+func (ps *ProductService) checkVariant(ctx context.Context, productID int, req params.UpdateVariantRequest, exceptID int) error {
+	exist, err := ps.db.VariantAttributesExist(ctx, productID, req.Attributes, exceptID)
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if exist {
+		return errs.AlreadyExistError{
+			Message: fmt.Sprintf("variant %s of product %d", formatAttributes(req.Attributes), productID),
+		}
+	}
+
+	if req.SKU != "" {
+		exist, err := ps.db.VariantSKUExists(ctx, req.SKU, exceptID)
+		if err != nil {
+			return fmt.Errorf("db error: %w", err)
+		}
+
+		if exist {
+			return errs.AlreadyExistError{
+				Message: fmt.Sprintf("sku %s", req.SKU),
+			}
+		}
+	}
+
+	return nil
+}
+
+// formatAttributes writes the attributes as name:value sorted by name, the
+// same way they are given to the variant filter.
+func formatAttributes(attributes map[string]string) string {
+	pairs := make([]string, 0, len(attributes))
+	for name, value := range attributes {
+		pairs = append(pairs, name+":"+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func newVariantResponse(variant domain.Variant) params.VariantResponse {
+	return params.VariantResponse{
+		ID:         variant.ID,
+		SKU:        variant.SKU,
+		Attributes: variant.Attributes,
+		Price:      variant.Price.Amount,
+		Currency:   variant.Price.Currency,
+		FinalPrice: variant.Price.Amount,
+		CreatedAt:  variant.CreatedAt,
+	}
+}
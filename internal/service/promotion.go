@@ -0,0 +1,238 @@
+package service
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/service/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	PromotionRepo interface {
+		ListPromotions(ctx context.Context) ([]domain.Promotion, error)
+		GetPromotion(ctx context.Context, id int) (*domain.Promotion, error)
+		CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*domain.Promotion, error)
+		DeletePromotion(ctx context.Context, id int) error
+		GetProduct(ctx context.Context, id int) (*domain.Product, error)
+		GetProductType(ctx context.Context, name string) (*domain.ProductType, error)
+	}
+
+	PromotionService struct {
+		db    PromotionRepo
+		cache CacheRepo
+	}
+)
+
+func NewPromotionService(repo PromotionRepo, cache CacheRepo) *PromotionService {
+	return &PromotionService{
+		db:    repo,
+		cache: cache,
+	}
+}
+
+func (pms *PromotionService) ListPromotions(ctx context.Context) ([]params.PromotionResponse, error) {
+	promotions, err := pms.db.ListPromotions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := make([]params.PromotionResponse, 0, len(promotions))
+	for _, promotion := range promotions {
+		res = append(res, newPromotionResponse(promotion))
+	}
+
+	return res, nil
+}
+
+func (pms *PromotionService) GetPromotion(ctx context.Context, id int) (*params.PromotionResponse, error) {
+	promotion, err := pms.db.GetPromotion(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("promotion %d", id),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := newPromotionResponse(*promotion)
+	return &res, nil
+}
+
+func (pms *PromotionService) CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*params.PromotionResponse, error) {
+	if req.ProductID != 0 {
+		_, err := pms.db.GetProduct(ctx, req.ProductID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("product %d not valid", req.ProductID)}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+	}
+
+	if req.ProductType != "" {
+		_, err := pms.db.GetProductType(ctx, req.ProductType)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ValidationError{Message: fmt.Sprintf("%s not valid product type", req.ProductType)}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("db error: %w", err)
+		}
+	}
+
+	promotion, err := pms.db.CreatePromotion(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if err := pms.cache.FlushPromotions(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	res := newPromotionResponse(*promotion)
+	return &res, nil
+}
+
+func (pms *PromotionService) DeletePromotion(ctx context.Context, id int) error {
+	err := pms.db.DeletePromotion(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errs.NotFoundError{
+			Message: fmt.Sprintf("promotion %d", id),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("db error: %w", err)
+	}
+
+	if err := pms.cache.FlushPromotions(ctx); err != nil {
+		return fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	return nil
+}
+
+func newPromotionResponse(promotion domain.Promotion) params.PromotionResponse {
+	return params.PromotionResponse{
+		ID:            promotion.ID,
+		Name:          promotion.Name,
+		DiscountType:  promotion.DiscountType,
+		DiscountValue: promotion.DiscountValue,
+		Currency:      promotion.Currency,
+		ProductID:     promotion.ProductID,
+		ProductType:   promotion.ProductTypeName,
+		StartsAt:      promotion.StartsAt,
+		EndsAt:        promotion.EndsAt,
+		CreatedAt:     promotion.CreatedAt,
+	}
+}
+
+// activePromotions returns the promotions active at the given time, or now
+// when it is nil. Only the current ones are cached.
+func (ps *ProductService) activePromotions(ctx context.Context, at *time.Time) ([]domain.Promotion, error) {
+	t := time.Now()
+	if at != nil {
+		t = *at
+	}
+
+	var promotions []domain.Promotion
+	var err error
+	if at != nil {
+		promotions, err = ps.db.ListPromotionsEndingAfter(ctx, t)
+		if err != nil {
+			return nil, fmt.Errorf("db error (promotions): %w", err)
+		}
+	} else {
+		promotions, err = ps.cache.GetCachedPromotions(ctx)
+		if err != nil && err != redis.Nil {
+			return nil, fmt.Errorf("cache error (promotions): %w", err)
+		}
+
+		if err == redis.Nil {
+			promotions, err = ps.db.ListPromotionsEndingAfter(ctx, t)
+			if err != nil {
+				return nil, fmt.Errorf("db error (promotions): %w", err)
+			}
+
+			if err = ps.cache.CachePromotions(ctx, promotions); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	active := []domain.Promotion{}
+	for _, promotion := range promotions {
+		if !promotion.StartsAt.After(t) && promotion.EndsAt.After(t) {
+			active = append(active, promotion)
+		}
+	}
+
+	return active, nil
+}
+
+// applyPromotions sets the final price of the product and of each of its
+// variants from the promotion giving the lowest price, the oldest promotion
+// wins a tie. Fixed discounts only apply to prices of their currency.
+func applyPromotions(product *params.ProductResponse, promotions []domain.Promotion) {
+	product.FinalPrice, product.Promotion = bestPromotion(product, product.Price, product.Currency, promotions)
+
+	for i := range product.Variants {
+		variant := &product.Variants[i]
+		variant.FinalPrice, variant.Promotion = bestPromotion(product, variant.Price, variant.Currency, promotions)
+	}
+}
+
+// bestPromotion returns the lowest price reached by the promotions of the product.
+func bestPromotion(product *params.ProductResponse, price int, currency string, promotions []domain.Promotion) (int, *params.AppliedPromotionResponse) {
+	finalPrice := price
+	var applied *params.AppliedPromotionResponse
+
+	for _, promotion := range promotions {
+		if promotion.DiscountType == params.DiscountFixed && promotion.Currency != currency {
+			continue
+		}
+
+		switch {
+		case promotion.ProductID != 0:
+			if promotion.ProductID != product.ID {
+				continue
+			}
+		case promotion.ProductTypeName != "":
+			if !slices.Contains(promotion.ProductTypes, product.Type) {
+				continue
+			}
+		}
+
+		if discounted := discountedPrice(price, promotion); discounted < finalPrice {
+			finalPrice = discounted
+			applied = &params.AppliedPromotionResponse{
+				ID:            promotion.ID,
+				Name:          promotion.Name,
+				DiscountType:  promotion.DiscountType,
+				DiscountValue: promotion.DiscountValue,
+				Currency:      promotion.Currency,
+				EndsAt:        promotion.EndsAt,
+			}
+		}
+	}
+
+	return finalPrice, applied
+}
+
+// discountedPrice never goes below zero, a percentage discount is rounded down.
+func discountedPrice(price int, promotion domain.Promotion) int {
+	discount := promotion.DiscountValue
+	if promotion.DiscountType == params.DiscountPercentage {
+		discount = price * promotion.DiscountValue / 100
+	}
+
+	return max(price-discount, 0)
+}
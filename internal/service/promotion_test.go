@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockservice "github.com/elangreza/lion-superindo/mock/service"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestPromotionServiceSuite struct {
+	suite.Suite
+
+	MockPromotionRepo *mockservice.MockPromotionRepo
+	MockCacheRepo     *mockservice.MockCacheRepo
+	Pms               *PromotionService
+	Ctrl              *gomock.Controller
+}
+
+func (suite *TestPromotionServiceSuite) SetupSuite() {
+	suite.Ctrl = gomock.NewController(suite.T())
+	suite.MockPromotionRepo = mockservice.NewMockPromotionRepo(suite.Ctrl)
+	suite.MockCacheRepo = mockservice.NewMockCacheRepo(suite.Ctrl)
+	suite.Pms = NewPromotionService(suite.MockPromotionRepo, suite.MockCacheRepo)
+}
+
+func (suite *TestPromotionServiceSuite) TearDownSuite() {
+	suite.Ctrl.Finish()
+}
+
+func TestPromotionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TestPromotionServiceSuite))
+}
+
+func (suite *TestPromotionServiceSuite) TestPromotionService_GetPromotion() {
+	ctx := context.Background()
+
+	suite.Run("promotion not found", func() {
+		suite.MockPromotionRepo.EXPECT().GetPromotion(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Pms.GetPromotion(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockPromotionRepo.EXPECT().GetPromotion(ctx, 1).Return(&domain.Promotion{
+			ID: 1, Name: "payday", DiscountType: params.DiscountFixed, DiscountValue: 500, ProductTypeName: "buah",
+		}, nil)
+
+		got, err := suite.Pms.GetPromotion(ctx, 1)
+		suite.NoError(err)
+		suite.Equal("payday", got.Name)
+		suite.Equal("buah", got.ProductType)
+	})
+}
+
+func (suite *TestPromotionServiceSuite) TestPromotionService_CreatePromotion() {
+	ctx := context.Background()
+	startsAt := time.Now()
+	endsAt := startsAt.Add(24 * time.Hour)
+
+	suite.Run("product not valid", func() {
+		req := params.CreatePromotionRequest{Name: "flash sale", DiscountType: params.DiscountPercentage, DiscountValue: 10, ProductID: 9, StartsAt: startsAt, EndsAt: endsAt}
+		suite.MockPromotionRepo.EXPECT().GetProduct(ctx, 9).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Pms.CreatePromotion(ctx, req)
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("product type not valid", func() {
+		req := params.CreatePromotionRequest{Name: "flash sale", DiscountType: params.DiscountPercentage, DiscountValue: 10, ProductType: "mainan", StartsAt: startsAt, EndsAt: endsAt}
+		suite.MockPromotionRepo.EXPECT().GetProductType(ctx, "mainan").Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Pms.CreatePromotion(ctx, req)
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		req := params.CreatePromotionRequest{Name: "flash sale", DiscountType: params.DiscountPercentage, DiscountValue: 10, ProductType: "buah", StartsAt: startsAt, EndsAt: endsAt}
+		suite.MockPromotionRepo.EXPECT().GetProductType(ctx, "buah").Return(&domain.ProductType{Name: "buah"}, nil)
+		suite.MockPromotionRepo.EXPECT().CreatePromotion(ctx, req).Return(&domain.Promotion{
+			ID: 3, Name: "flash sale", DiscountType: params.DiscountPercentage, DiscountValue: 10, ProductTypeName: "buah", StartsAt: startsAt, EndsAt: endsAt,
+		}, nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+
+		got, err := suite.Pms.CreatePromotion(ctx, req)
+		suite.NoError(err)
+		suite.Equal(3, got.ID)
+	})
+}
+
+func (suite *TestPromotionServiceSuite) TestPromotionService_DeletePromotion() {
+	ctx := context.Background()
+
+	suite.Run("promotion not found", func() {
+		suite.MockPromotionRepo.EXPECT().DeletePromotion(ctx, 1).Return(sql.ErrNoRows)
+
+		err := suite.Pms.DeletePromotion(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+	})
+
+	suite.Run("success", func() {
+		suite.MockPromotionRepo.EXPECT().DeletePromotion(ctx, 1).Return(nil)
+		suite.MockCacheRepo.EXPECT().FlushPromotions(ctx).Return(nil)
+
+		suite.NoError(suite.Pms.DeletePromotion(ctx, 1))
+	})
+}
+
+func (suite *TestProductServiceSuite) TestProductService_GetProduct_Promotion() {
+	ctx := context.Background()
+	product := &domain.Product{ID: 1, Name: "apel", Price: domain.Money{Amount: 10000, Currency: "IDR"}, ProductType: domain.ProductType{Name: "apel impor"}}
+	now := time.Now()
+	promotions := []domain.Promotion{
+		{ID: 1, Name: "catalog", DiscountType: params.DiscountPercentage, DiscountValue: 5, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 2, Name: "buah", DiscountType: params.DiscountFixed, DiscountValue: 2000, Currency: "IDR", ProductTypeName: "buah", ProductTypes: []string{"buah", "apel impor"}, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 3, Name: "not started", DiscountType: params.DiscountPercentage, DiscountValue: 90, StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)},
+		{ID: 4, Name: "other product", DiscountType: params.DiscountPercentage, DiscountValue: 90, ProductID: 2, StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+		{ID: 5, Name: "other currency", DiscountType: params.DiscountFixed, DiscountValue: 9000, Currency: "USD", StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)},
+	}
+
+	suite.Run("best active promotion is applied", func() {
+		suite.MockCacheRepo.EXPECT().GetCachedProduct(ctx, 1).Return(product, nil)
+		suite.MockCacheRepo.EXPECT().GetCachedPromotions(ctx).Return(nil, redis.Nil)
+		suite.MockDbRepo.EXPECT().ListPromotionsEndingAfter(ctx, gomock.Any()).Return(promotions, nil)
+		suite.MockCacheRepo.EXPECT().CachePromotions(ctx, promotions).Return(nil)
+
+		got, err := suite.Ps.GetProduct(ctx, 1)
+		suite.NoError(err)
+		suite.Equal(10000, got.Price)
+		suite.Equal(8000, got.FinalPrice)
+		suite.Require().NotNil(got.Promotion)
+		suite.Equal(2, got.Promotion.ID)
+	})
+}
+
+func TestDiscountedPrice(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     int
+		promotion domain.Promotion
+		want      int
+	}{
+		{name: "percentage", price: 1999, promotion: domain.Promotion{DiscountType: params.DiscountPercentage, DiscountValue: 10}, want: 1800},
+		{name: "fixed", price: 1999, promotion: domain.Promotion{DiscountType: params.DiscountFixed, DiscountValue: 500}, want: 1499},
+		{name: "fixed above the price", price: 300, promotion: domain.Promotion{DiscountType: params.DiscountFixed, DiscountValue: 500}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discountedPrice(tt.price, tt.promotion); got != tt.want {
+				t.Errorf("discountedPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/service/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+// reservationBatchSize is the number of expired reservations released in one transaction.
+const reservationBatchSize = 500
+
+type (
+	ReservationRepo interface {
+		GetReservation(ctx context.Context, id int) (*domain.Reservation, error)
+		CreateReservation(ctx context.Context, req params.CreateReservationRequest, expiresAt time.Time) (*domain.Reservation, int, error)
+		ConfirmReservation(ctx context.Context, id int) (*domain.Reservation, error)
+		CancelReservation(ctx context.Context, id int) (*domain.Reservation, int, error)
+		ExpireReservations(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error)
+		GetStore(ctx context.Context, id int) (*domain.Store, error)
+		GetProduct(ctx context.Context, id int) (*domain.Product, error)
+	}
+
+	ReservationService struct {
+		db    ReservationRepo
+		cache CacheRepo
+	}
+)
+
+func NewReservationService(repo ReservationRepo, cache CacheRepo) *ReservationService {
+	return &ReservationService{
+		db:    repo,
+		cache: cache,
+	}
+}
+
+func (rs *ReservationService) GetReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	reservation, err := rs.getReservation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newReservationResponse(*reservation)
+	return &res, nil
+}
+
+func (rs *ReservationService) CreateReservation(ctx context.Context, req params.CreateReservationRequest) (*params.ReservationResponse, error) {
+	_, err := rs.db.GetStore(ctx, req.StoreID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("store %d not valid", req.StoreID)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	_, err = rs.db.GetProduct(ctx, req.ProductID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("product %d not valid", req.ProductID)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	expiresAt := time.Now().Add(time.Duration(req.TTL) * time.Second)
+	reservation, stock, err := rs.db.CreateReservation(ctx, req, expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ConflictError{
+			Message: fmt.Sprintf("not enough stock of product %d at store %d", req.ProductID, req.StoreID),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	// the reservation took the last items, the cached in_stock_at lists change
+	if stock == 0 {
+		if err := rs.cache.FlushAllProducts(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+	}
+
+	res := newReservationResponse(*reservation)
+	return &res, nil
+}
+
+func (rs *ReservationService) ConfirmReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	reservation, err := rs.db.ConfirmReservation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, rs.notPendingError(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := newReservationResponse(*reservation)
+	return &res, nil
+}
+
+func (rs *ReservationService) CancelReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	reservation, stock, err := rs.db.CancelReservation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, rs.notPendingError(ctx, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	// the product is back in stock, the cached in_stock_at lists change
+	if stock == reservation.Quantity {
+		if err := rs.cache.FlushAllProducts(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+	}
+
+	res := newReservationResponse(*reservation)
+	return &res, nil
+}
+
+// ExpireReservations puts the stock of every expired reservation back and
+// returns the number of released reservations.
+func (rs *ReservationService) ExpireReservations(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	var expired int
+	for {
+		reservations, err := rs.db.ExpireReservations(ctx, now, reservationBatchSize)
+		if err != nil {
+			return expired, fmt.Errorf("db error: %w", err)
+		}
+
+		expired += len(reservations)
+		if len(reservations) < reservationBatchSize {
+			break
+		}
+	}
+
+	if expired == 0 {
+		return 0, nil
+	}
+
+	if err := rs.cache.FlushAllProducts(ctx); err != nil {
+		return expired, fmt.Errorf("failed to flush cache: %w", err)
+	}
+
+	return expired, nil
+}
+
+// notPendingError explains why a reservation cannot be confirmed or cancelled.
+func (rs *ReservationService) notPendingError(ctx context.Context, id int) error {
+	reservation, err := rs.getReservation(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	status := reservation.Status
+	// not released by the sweeper yet
+	if status == params.ReservationPending && !reservation.ExpiresAt.After(time.Now()) {
+		status = params.ReservationExpired
+	}
+
+	return errs.ConflictError{
+		Message: fmt.Sprintf("reservation %d is %s", id, status),
+	}
+}
+
+func (rs *ReservationService) getReservation(ctx context.Context, id int) (*domain.Reservation, error) {
+	reservation, err := rs.db.GetReservation(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("reservation %d", id),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func newReservationResponse(reservation domain.Reservation) params.ReservationResponse {
+	return params.ReservationResponse{
+		ID:        reservation.ID,
+		StoreID:   reservation.StoreID,
+		ProductID: reservation.ProductID,
+		Quantity:  reservation.Quantity,
+		Status:    reservation.Status,
+		ExpiresAt: reservation.ExpiresAt,
+		CreatedAt: reservation.CreatedAt,
+		UpdatedAt: reservation.UpdatedAt,
+	}
+}
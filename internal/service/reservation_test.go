@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockservice "github.com/elangreza/lion-superindo/mock/service"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestReservationServiceSuite struct {
+	suite.Suite
+
+	MockReservationRepo *mockservice.MockReservationRepo
+	MockCacheRepo       *mockservice.MockCacheRepo
+	Rs                  *ReservationService
+	Ctrl                *gomock.Controller
+}
+
+func (suite *TestReservationServiceSuite) SetupSuite() {
+	suite.Ctrl = gomock.NewController(suite.T())
+	suite.MockReservationRepo = mockservice.NewMockReservationRepo(suite.Ctrl)
+	suite.MockCacheRepo = mockservice.NewMockCacheRepo(suite.Ctrl)
+	suite.Rs = NewReservationService(suite.MockReservationRepo, suite.MockCacheRepo)
+}
+
+func (suite *TestReservationServiceSuite) TearDownSuite() {
+	suite.Ctrl.Finish()
+}
+
+func TestReservationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TestReservationServiceSuite))
+}
+
+func (suite *TestReservationServiceSuite) TestReservationService_CreateReservation() {
+	ctx := context.Background()
+	req := params.CreateReservationRequest{StoreID: 1, ProductID: 101, Quantity: 3, TTL: 60}
+
+	suite.Run("store not valid", func() {
+		suite.MockReservationRepo.EXPECT().GetStore(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Rs.CreateReservation(ctx, req)
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("not enough stock", func() {
+		suite.MockReservationRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockReservationRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101}, nil)
+		suite.MockReservationRepo.EXPECT().CreateReservation(ctx, req, gomock.Any()).Return(nil, 0, sql.ErrNoRows)
+
+		got, err := suite.Rs.CreateReservation(ctx, req)
+		suite.ErrorAs(err, &errs.ConflictError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockReservationRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockReservationRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101}, nil)
+		suite.MockReservationRepo.EXPECT().CreateReservation(ctx, req, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ params.CreateReservationRequest, expiresAt time.Time) (*domain.Reservation, int, error) {
+				suite.WithinDuration(time.Now().Add(time.Minute), expiresAt, time.Second)
+				return &domain.Reservation{ID: 7, StoreID: 1, ProductID: 101, Quantity: 3, Status: params.ReservationPending, ExpiresAt: expiresAt}, 2, nil
+			})
+
+		got, err := suite.Rs.CreateReservation(ctx, req)
+		suite.NoError(err)
+		suite.Equal(7, got.ID)
+	})
+
+	suite.Run("last items flush the cached products", func() {
+		suite.MockReservationRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockReservationRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101}, nil)
+		suite.MockReservationRepo.EXPECT().CreateReservation(ctx, req, gomock.Any()).
+			Return(&domain.Reservation{ID: 8, StoreID: 1, ProductID: 101, Quantity: 3, Status: params.ReservationPending}, 0, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+
+		got, err := suite.Rs.CreateReservation(ctx, req)
+		suite.NoError(err)
+		suite.Equal(8, got.ID)
+	})
+}
+
+func (suite *TestReservationServiceSuite) TestReservationService_ConfirmReservation() {
+	ctx := context.Background()
+
+	suite.Run("reservation not found", func() {
+		suite.MockReservationRepo.EXPECT().ConfirmReservation(ctx, 7).Return(nil, sql.ErrNoRows)
+		suite.MockReservationRepo.EXPECT().GetReservation(ctx, 7).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Rs.ConfirmReservation(ctx, 7)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("reservation expired before the sweeper ran", func() {
+		suite.MockReservationRepo.EXPECT().ConfirmReservation(ctx, 7).Return(nil, sql.ErrNoRows)
+		suite.MockReservationRepo.EXPECT().GetReservation(ctx, 7).Return(&domain.Reservation{
+			ID: 7, Status: params.ReservationPending, ExpiresAt: time.Now().Add(-time.Second),
+		}, nil)
+
+		got, err := suite.Rs.ConfirmReservation(ctx, 7)
+		suite.EqualError(err, "reservation 7 is expired")
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockReservationRepo.EXPECT().ConfirmReservation(ctx, 7).Return(&domain.Reservation{ID: 7, Status: params.ReservationConfirmed}, nil)
+
+		got, err := suite.Rs.ConfirmReservation(ctx, 7)
+		suite.NoError(err)
+		suite.Equal(params.ReservationConfirmed, got.Status)
+	})
+}
+
+func (suite *TestReservationServiceSuite) TestReservationService_CancelReservation() {
+	ctx := context.Background()
+
+	suite.Run("reservation already confirmed", func() {
+		suite.MockReservationRepo.EXPECT().CancelReservation(ctx, 7).Return(nil, 0, sql.ErrNoRows)
+		suite.MockReservationRepo.EXPECT().GetReservation(ctx, 7).Return(&domain.Reservation{ID: 7, Status: params.ReservationConfirmed}, nil)
+
+		got, err := suite.Rs.CancelReservation(ctx, 7)
+		suite.ErrorAs(err, &errs.ConflictError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("back in stock flushes the cached products", func() {
+		suite.MockReservationRepo.EXPECT().CancelReservation(ctx, 7).Return(&domain.Reservation{ID: 7, Quantity: 3, Status: params.ReservationCancelled}, 3, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+
+		got, err := suite.Rs.CancelReservation(ctx, 7)
+		suite.NoError(err)
+		suite.Equal(params.ReservationCancelled, got.Status)
+	})
+}
+
+func (suite *TestReservationServiceSuite) TestReservationService_ExpireReservations() {
+	ctx := context.Background()
+
+	suite.Run("nothing expired", func() {
+		suite.MockReservationRepo.EXPECT().ExpireReservations(ctx, gomock.Any(), reservationBatchSize).Return(nil, nil)
+
+		got, err := suite.Rs.ExpireReservations(ctx)
+		suite.NoError(err)
+		suite.Equal(0, got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockReservationRepo.EXPECT().ExpireReservations(ctx, gomock.Any(), reservationBatchSize).
+			Return([]domain.Reservation{{ID: 7}, {ID: 8}}, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+
+		got, err := suite.Rs.ExpireReservations(ctx)
+		suite.NoError(err)
+		suite.Equal(2, got)
+	})
+}
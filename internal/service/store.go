@@ -0,0 +1,168 @@
+package service
+
+//go:generate mockgen -source $GOFILE -destination ../../mock/service/mock_$GOFILE -package mock$GOPACKAGE
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+)
+
+type (
+	StoreRepo interface {
+		ListStores(ctx context.Context) ([]domain.Store, error)
+		GetStore(ctx context.Context, id int) (*domain.Store, error)
+		CreateStore(ctx context.Context, name string) (*domain.Store, error)
+		StoreNameExists(ctx context.Context, name string) (bool, error)
+		ListStockLevels(ctx context.Context, storeID int) ([]domain.StockLevel, error)
+		AdjustStock(ctx context.Context, storeID, productID, delta int) (*domain.StockLevel, error)
+		GetProduct(ctx context.Context, id int) (*domain.Product, error)
+	}
+
+	StoreService struct {
+		db    StoreRepo
+		cache CacheRepo
+	}
+)
+
+func NewStoreService(repo StoreRepo, cache CacheRepo) *StoreService {
+	return &StoreService{
+		db:    repo,
+		cache: cache,
+	}
+}
+
+func (ss *StoreService) ListStores(ctx context.Context) ([]params.StoreResponse, error) {
+	stores, err := ss.db.ListStores(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := make([]params.StoreResponse, 0, len(stores))
+	for _, store := range stores {
+		res = append(res, newStoreResponse(store))
+	}
+
+	return res, nil
+}
+
+func (ss *StoreService) GetStore(ctx context.Context, id int) (*params.StoreResponse, error) {
+	store, err := ss.getStore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := newStoreResponse(*store)
+	return &res, nil
+}
+
+func (ss *StoreService) CreateStore(ctx context.Context, req params.CreateStoreRequest) (*params.StoreResponse, error) {
+	exists, err := ss.db.StoreNameExists(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	if exists {
+		return nil, errs.AlreadyExistError{
+			Message: fmt.Sprintf("store %s", req.Name),
+		}
+	}
+
+	store, err := ss.db.CreateStore(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := newStoreResponse(*store)
+	return &res, nil
+}
+
+func (ss *StoreService) ListStockLevels(ctx context.Context, storeID int) ([]params.StockLevelResponse, error) {
+	if _, err := ss.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	levels, err := ss.db.ListStockLevels(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	res := make([]params.StockLevelResponse, 0, len(levels))
+	for _, level := range levels {
+		res = append(res, newStockLevelResponse(level))
+	}
+
+	return res, nil
+}
+
+func (ss *StoreService) AdjustStock(ctx context.Context, storeID int, req params.AdjustStockRequest) (*params.StockLevelResponse, error) {
+	if _, err := ss.getStore(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	product, err := ss.db.GetProduct(ctx, req.ProductID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ValidationError{Message: fmt.Sprintf("product %d not valid", req.ProductID)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	level, err := ss.db.AdjustStock(ctx, storeID, req.ProductID, req.Delta)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ConflictError{
+			Message: fmt.Sprintf("not enough stock of product %d at store %d", req.ProductID, storeID),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	// the cached in_stock_at lists only change when the product runs out or comes back
+	if wasInStock := level.Quantity-req.Delta > 0; wasInStock != (level.Quantity > 0) {
+		if err := ss.cache.FlushAllProducts(ctx); err != nil {
+			return nil, fmt.Errorf("failed to flush cache: %w", err)
+		}
+	}
+
+	level.ProductName = product.Name
+	res := newStockLevelResponse(*level)
+	return &res, nil
+}
+
+func (ss *StoreService) getStore(ctx context.Context, id int) (*domain.Store, error) {
+	store, err := ss.db.GetStore(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.NotFoundError{
+			Message: fmt.Sprintf("store %d", id),
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("db error: %w", err)
+	}
+
+	return store, nil
+}
+
+func newStoreResponse(store domain.Store) params.StoreResponse {
+	return params.StoreResponse{
+		ID:        store.ID,
+		Name:      store.Name,
+		CreatedAt: store.CreatedAt,
+	}
+}
+
+func newStockLevelResponse(level domain.StockLevel) params.StockLevelResponse {
+	return params.StockLevelResponse{
+		StoreID:     level.StoreID,
+		ProductID:   level.ProductID,
+		ProductName: level.ProductName,
+		Quantity:    level.Quantity,
+		UpdatedAt:   level.UpdatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/elangreza/lion-superindo/internal/domain"
+	"github.com/elangreza/lion-superindo/internal/params"
+	mockservice "github.com/elangreza/lion-superindo/mock/service"
+	errs "github.com/elangreza/lion-superindo/pkg/error"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TestStoreServiceSuite struct {
+	suite.Suite
+
+	MockStoreRepo *mockservice.MockStoreRepo
+	MockCacheRepo *mockservice.MockCacheRepo
+	Ss            *StoreService
+	Ctrl          *gomock.Controller
+}
+
+func (suite *TestStoreServiceSuite) SetupSuite() {
+	suite.Ctrl = gomock.NewController(suite.T())
+	suite.MockStoreRepo = mockservice.NewMockStoreRepo(suite.Ctrl)
+	suite.MockCacheRepo = mockservice.NewMockCacheRepo(suite.Ctrl)
+	suite.Ss = NewStoreService(suite.MockStoreRepo, suite.MockCacheRepo)
+}
+
+func (suite *TestStoreServiceSuite) TearDownSuite() {
+	suite.Ctrl.Finish()
+}
+
+func TestStoreServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TestStoreServiceSuite))
+}
+
+func (suite *TestStoreServiceSuite) TestStoreService_CreateStore() {
+	ctx := context.Background()
+	req := params.CreateStoreRequest{Name: "Kemang"}
+
+	suite.Run("name already exists", func() {
+		suite.MockStoreRepo.EXPECT().StoreNameExists(ctx, "Kemang").Return(true, nil)
+
+		got, err := suite.Ss.CreateStore(ctx, req)
+		suite.ErrorAs(err, &errs.AlreadyExistError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockStoreRepo.EXPECT().StoreNameExists(ctx, "Kemang").Return(false, nil)
+		suite.MockStoreRepo.EXPECT().CreateStore(ctx, "Kemang").Return(&domain.Store{ID: 1, Name: "Kemang"}, nil)
+
+		got, err := suite.Ss.CreateStore(ctx, req)
+		suite.NoError(err)
+		suite.Equal(1, got.ID)
+	})
+}
+
+func (suite *TestStoreServiceSuite) TestStoreService_ListStockLevels() {
+	ctx := context.Background()
+
+	suite.Run("store not found", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ss.ListStockLevels(ctx, 1)
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("success", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().ListStockLevels(ctx, 1).Return([]domain.StockLevel{
+			{StoreID: 1, ProductID: 101, ProductName: "Sawi", Quantity: 4},
+		}, nil)
+
+		got, err := suite.Ss.ListStockLevels(ctx, 1)
+		suite.NoError(err)
+		suite.Equal([]params.StockLevelResponse{{StoreID: 1, ProductID: 101, ProductName: "Sawi", Quantity: 4}}, got)
+	})
+}
+
+func (suite *TestStoreServiceSuite) TestStoreService_AdjustStock() {
+	ctx := context.Background()
+
+	suite.Run("store not found", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: 5})
+		suite.ErrorAs(err, &errs.NotFoundError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("product not valid", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().GetProduct(ctx, 101).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: 5})
+		suite.ErrorAs(err, &errs.ValidationError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("not enough stock", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101, Name: "Sawi"}, nil)
+		suite.MockStoreRepo.EXPECT().AdjustStock(ctx, 1, 101, -5).Return(nil, sql.ErrNoRows)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: -5})
+		suite.ErrorAs(err, &errs.ConflictError{})
+		suite.Nil(got)
+	})
+
+	suite.Run("still in stock keeps the cache", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101, Name: "Sawi"}, nil)
+		suite.MockStoreRepo.EXPECT().AdjustStock(ctx, 1, 101, -2).Return(&domain.StockLevel{StoreID: 1, ProductID: 101, Quantity: 3}, nil)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: -2})
+		suite.NoError(err)
+		suite.Equal(3, got.Quantity)
+		suite.Equal("Sawi", got.ProductName)
+	})
+
+	suite.Run("sold out flushes the cached products", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101, Name: "Sawi"}, nil)
+		suite.MockStoreRepo.EXPECT().AdjustStock(ctx, 1, 101, -3).Return(&domain.StockLevel{StoreID: 1, ProductID: 101, Quantity: 0}, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: -3})
+		suite.NoError(err)
+		suite.Equal(0, got.Quantity)
+	})
+
+	suite.Run("restock flushes the cached products", func() {
+		suite.MockStoreRepo.EXPECT().GetStore(ctx, 1).Return(&domain.Store{ID: 1}, nil)
+		suite.MockStoreRepo.EXPECT().GetProduct(ctx, 101).Return(&domain.Product{ID: 101, Name: "Sawi"}, nil)
+		suite.MockStoreRepo.EXPECT().AdjustStock(ctx, 1, 101, 10).Return(&domain.StockLevel{StoreID: 1, ProductID: 101, Quantity: 10}, nil)
+		suite.MockCacheRepo.EXPECT().FlushAllProducts(ctx).Return(nil)
+
+		got, err := suite.Ss.AdjustStock(ctx, 1, params.AdjustStockRequest{ProductID: 101, Delta: 10})
+		suite.NoError(err)
+		suite.Equal(10, got.Quantity)
+	})
+}
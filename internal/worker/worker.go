@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Job is run on every tick of a Worker. An error is logged and the job runs
+// again on the next tick.
+type Job func(ctx context.Context) error
+
+// Worker runs a job periodically in the background until it is shut down.
+type Worker struct {
+	name     string
+	interval time.Duration
+	job      Job
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(name string, interval time.Duration, job Job) *Worker {
+	return &Worker{
+		name:     name,
+		interval: interval,
+		job:      job,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the job right away, then once per interval.
+func (wk *Worker) Start(ctx context.Context) {
+	ctx, wk.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(wk.done)
+
+		ticker := time.NewTicker(wk.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := wk.job(ctx); err != nil && ctx.Err() == nil {
+				slog.Error(wk.name, "err", err.Error())
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Shutdown stops the worker and waits for the running job to return.
+func (wk *Worker) Shutdown(ctx context.Context) error {
+	if wk.cancel == nil {
+		return nil
+	}
+	wk.cancel()
+
+	select {
+	case <-wk.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
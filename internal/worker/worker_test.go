@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker(t *testing.T) {
+	var runs atomic.Int32
+	wk := New("test", time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("keeps running after an error")
+	})
+
+	wk.Start(context.Background())
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+
+	assert.NoError(t, wk.Shutdown(context.Background()))
+	stopped := runs.Load()
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestWorker_Shutdown_Waits_For_The_Job(t *testing.T) {
+	started := make(chan struct{})
+	wk := New("test", time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		return ctx.Err()
+	})
+
+	wk.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, wk.Shutdown(ctx), context.DeadlineExceeded)
+	assert.NoError(t, wk.Shutdown(context.Background()))
+}
+
+func TestWorker_Shutdown_Before_Start(t *testing.T) {
+	wk := New("test", time.Second, func(ctx context.Context) error { return nil })
+	assert.NoError(t, wk.Shutdown(context.Background()))
+}
@@ -11,6 +11,7 @@ package mockhandler
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	params "github.com/elangreza/lion-superindo/internal/params"
@@ -41,6 +42,35 @@ func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
 	return m.recorder
 }
 
+// BulkCreateProducts mocks base method.
+func (m *MockProductService) BulkCreateProducts(ctx context.Context, req params.BulkCreateProductsRequest) (*params.BulkCreateProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreateProducts", ctx, req)
+	ret0, _ := ret[0].(*params.BulkCreateProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreateProducts indicates an expected call of BulkCreateProducts.
+func (mr *MockProductServiceMockRecorder) BulkCreateProducts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreateProducts", reflect.TypeOf((*MockProductService)(nil).BulkCreateProducts), ctx, req)
+}
+
+// CancelScheduledPrice mocks base method.
+func (m *MockProductService) CancelScheduledPrice(ctx context.Context, id, scheduledID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledPrice", ctx, id, scheduledID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduledPrice indicates an expected call of CancelScheduledPrice.
+func (mr *MockProductServiceMockRecorder) CancelScheduledPrice(ctx, id, scheduledID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledPrice", reflect.TypeOf((*MockProductService)(nil).CancelScheduledPrice), ctx, id, scheduledID)
+}
+
 // CreateProduct mocks base method.
 func (m *MockProductService) CreateProduct(ctx context.Context, req params.CreateProductRequest) (*params.CreateProductResponse, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +86,123 @@ func (mr *MockProductServiceMockRecorder) CreateProduct(ctx, req any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockProductService)(nil).CreateProduct), ctx, req)
 }
 
+// CreateVariant mocks base method.
+func (m *MockProductService) CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*params.VariantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, productID, req)
+	ret0, _ := ret[0].(*params.VariantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockProductServiceMockRecorder) CreateVariant(ctx, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockProductService)(nil).CreateVariant), ctx, productID, req)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockProductService) DeleteProduct(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockProductServiceMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockProductService)(nil).DeleteProduct), ctx, id)
+}
+
+// DeleteVariant mocks base method.
+func (m *MockProductService) DeleteVariant(ctx context.Context, productID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", ctx, productID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockProductServiceMockRecorder) DeleteVariant(ctx, productID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockProductService)(nil).DeleteVariant), ctx, productID, id)
+}
+
+// ExportProducts mocks base method.
+func (m *MockProductService) ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(params.ProductResponse) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", ctx, req, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductServiceMockRecorder) ExportProducts(ctx, req, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductService)(nil).ExportProducts), ctx, req, fn)
+}
+
+// GetProduct mocks base method.
+func (m *MockProductService) GetProduct(ctx context.Context, id int) (*params.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*params.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProductServiceMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductService)(nil).GetProduct), ctx, id)
+}
+
+// GetProductByBarcode mocks base method.
+func (m *MockProductService) GetProductByBarcode(ctx context.Context, barcode string) (*params.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*params.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockProductServiceMockRecorder) GetProductByBarcode(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockProductService)(nil).GetProductByBarcode), ctx, barcode)
+}
+
+// GetProductPrices mocks base method.
+func (m *MockProductService) GetProductPrices(ctx context.Context, id int) (*params.ProductPricesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductPrices", ctx, id)
+	ret0, _ := ret[0].(*params.ProductPricesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductPrices indicates an expected call of GetProductPrices.
+func (mr *MockProductServiceMockRecorder) GetProductPrices(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductPrices", reflect.TypeOf((*MockProductService)(nil).GetProductPrices), ctx, id)
+}
+
+// ImportProducts mocks base method.
+func (m *MockProductService) ImportProducts(ctx context.Context, src io.Reader) (*params.ImportProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProducts", ctx, src)
+	ret0, _ := ret[0].(*params.ImportProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockProductServiceMockRecorder) ImportProducts(ctx, src any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockProductService)(nil).ImportProducts), ctx, src)
+}
+
 // ListProducts mocks base method.
 func (m *MockProductService) ListProducts(ctx context.Context, args params.ListProductsQueryParams) (*params.ListProductsResponses, error) {
 	m.ctrl.T.Helper()
@@ -70,3 +217,123 @@ func (mr *MockProductServiceMockRecorder) ListProducts(ctx, args any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductService)(nil).ListProducts), ctx, args)
 }
+
+// ListScheduledPrices mocks base method.
+func (m *MockProductService) ListScheduledPrices(ctx context.Context, id int) ([]params.ScheduledPriceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledPrices", ctx, id)
+	ret0, _ := ret[0].([]params.ScheduledPriceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledPrices indicates an expected call of ListScheduledPrices.
+func (mr *MockProductServiceMockRecorder) ListScheduledPrices(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledPrices", reflect.TypeOf((*MockProductService)(nil).ListScheduledPrices), ctx, id)
+}
+
+// ListVariants mocks base method.
+func (m *MockProductService) ListVariants(ctx context.Context, productID int) ([]params.VariantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariants", ctx, productID)
+	ret0, _ := ret[0].([]params.VariantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariants indicates an expected call of ListVariants.
+func (mr *MockProductServiceMockRecorder) ListVariants(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariants", reflect.TypeOf((*MockProductService)(nil).ListVariants), ctx, productID)
+}
+
+// PatchProduct mocks base method.
+func (m *MockProductService) PatchProduct(ctx context.Context, id int, req params.PatchProductRequest) (*params.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchProduct", ctx, id, req)
+	ret0, _ := ret[0].(*params.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchProduct indicates an expected call of PatchProduct.
+func (mr *MockProductServiceMockRecorder) PatchProduct(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchProduct", reflect.TypeOf((*MockProductService)(nil).PatchProduct), ctx, id, req)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockProductService) RestoreProduct(ctx context.Context, id int) (*params.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(*params.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockProductServiceMockRecorder) RestoreProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockProductService)(nil).RestoreProduct), ctx, id)
+}
+
+// ScheduleProductPrice mocks base method.
+func (m *MockProductService) ScheduleProductPrice(ctx context.Context, id int, req params.ScheduleProductPriceRequest) (*params.ScheduledPriceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleProductPrice", ctx, id, req)
+	ret0, _ := ret[0].(*params.ScheduledPriceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleProductPrice indicates an expected call of ScheduleProductPrice.
+func (mr *MockProductServiceMockRecorder) ScheduleProductPrice(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleProductPrice", reflect.TypeOf((*MockProductService)(nil).ScheduleProductPrice), ctx, id, req)
+}
+
+// SuggestProducts mocks base method.
+func (m *MockProductService) SuggestProducts(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestProducts", ctx, req)
+	ret0, _ := ret[0].(*params.SuggestProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestProducts indicates an expected call of SuggestProducts.
+func (mr *MockProductServiceMockRecorder) SuggestProducts(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestProducts", reflect.TypeOf((*MockProductService)(nil).SuggestProducts), ctx, req)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockProductService) UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*params.ProductResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, id, req)
+	ret0, _ := ret[0].(*params.ProductResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockProductServiceMockRecorder) UpdateProduct(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockProductService)(nil).UpdateProduct), ctx, id, req)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockProductService) UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*params.VariantResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", ctx, productID, id, req)
+	ret0, _ := ret[0].(*params.VariantResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockProductServiceMockRecorder) UpdateVariant(ctx, productID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockProductService)(nil).UpdateVariant), ctx, productID, id, req)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: product_type.go
+//
+// Generated by this command:
+//
+//	mockgen -source product_type.go -destination ../../mock/handler/mock_product_type.go -package mockhandler
+//
+
+// Package mockhandler is a generated GoMock package.
+package mockhandler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductTypeService is a mock of ProductTypeService interface.
+type MockProductTypeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductTypeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProductTypeServiceMockRecorder is the mock recorder for MockProductTypeService.
+type MockProductTypeServiceMockRecorder struct {
+	mock *MockProductTypeService
+}
+
+// NewMockProductTypeService creates a new mock instance.
+func NewMockProductTypeService(ctrl *gomock.Controller) *MockProductTypeService {
+	mock := &MockProductTypeService{ctrl: ctrl}
+	mock.recorder = &MockProductTypeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductTypeService) EXPECT() *MockProductTypeServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateProductType mocks base method.
+func (m *MockProductTypeService) CreateProductType(ctx context.Context, req params.CreateProductTypeRequest) (*params.ProductTypeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductType", ctx, req)
+	ret0, _ := ret[0].(*params.ProductTypeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductType indicates an expected call of CreateProductType.
+func (mr *MockProductTypeServiceMockRecorder) CreateProductType(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductType", reflect.TypeOf((*MockProductTypeService)(nil).CreateProductType), ctx, req)
+}
+
+// DeleteProductType mocks base method.
+func (m *MockProductTypeService) DeleteProductType(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductType", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductType indicates an expected call of DeleteProductType.
+func (mr *MockProductTypeServiceMockRecorder) DeleteProductType(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductType", reflect.TypeOf((*MockProductTypeService)(nil).DeleteProductType), ctx, name)
+}
+
+// ListProductTypes mocks base method.
+func (m *MockProductTypeService) ListProductTypes(ctx context.Context) ([]params.ProductTypeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductTypes", ctx)
+	ret0, _ := ret[0].([]params.ProductTypeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductTypes indicates an expected call of ListProductTypes.
+func (mr *MockProductTypeServiceMockRecorder) ListProductTypes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTypes", reflect.TypeOf((*MockProductTypeService)(nil).ListProductTypes), ctx)
+}
+
+// MoveProductType mocks base method.
+func (m *MockProductTypeService) MoveProductType(ctx context.Context, name string, req params.MoveProductTypeRequest) (*params.ProductTypeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveProductType", ctx, name, req)
+	ret0, _ := ret[0].(*params.ProductTypeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveProductType indicates an expected call of MoveProductType.
+func (mr *MockProductTypeServiceMockRecorder) MoveProductType(ctx, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveProductType", reflect.TypeOf((*MockProductTypeService)(nil).MoveProductType), ctx, name, req)
+}
+
+// RenameProductType mocks base method.
+func (m *MockProductTypeService) RenameProductType(ctx context.Context, name string, req params.RenameProductTypeRequest) (*params.ProductTypeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameProductType", ctx, name, req)
+	ret0, _ := ret[0].(*params.ProductTypeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameProductType indicates an expected call of RenameProductType.
+func (mr *MockProductTypeServiceMockRecorder) RenameProductType(ctx, name, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameProductType", reflect.TypeOf((*MockProductTypeService)(nil).RenameProductType), ctx, name, req)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: promotion.go
+//
+// Generated by this command:
+//
+//	mockgen -source promotion.go -destination ../../mock/handler/mock_promotion.go -package mockhandler
+//
+
+// Package mockhandler is a generated GoMock package.
+package mockhandler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPromotionService is a mock of PromotionService interface.
+type MockPromotionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionServiceMockRecorder is the mock recorder for MockPromotionService.
+type MockPromotionServiceMockRecorder struct {
+	mock *MockPromotionService
+}
+
+// NewMockPromotionService creates a new mock instance.
+func NewMockPromotionService(ctrl *gomock.Controller) *MockPromotionService {
+	mock := &MockPromotionService{ctrl: ctrl}
+	mock.recorder = &MockPromotionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionService) EXPECT() *MockPromotionServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*params.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, req)
+	ret0, _ := ret[0].(*params.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionServiceMockRecorder) CreatePromotion(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionService)(nil).CreatePromotion), ctx, req)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionService) DeletePromotion(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionServiceMockRecorder) DeletePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionService)(nil).DeletePromotion), ctx, id)
+}
+
+// GetPromotion mocks base method.
+func (m *MockPromotionService) GetPromotion(ctx context.Context, id int) (*params.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(*params.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockPromotionServiceMockRecorder) GetPromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockPromotionService)(nil).GetPromotion), ctx, id)
+}
+
+// ListPromotions mocks base method.
+func (m *MockPromotionService) ListPromotions(ctx context.Context) ([]params.PromotionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", ctx)
+	ret0, _ := ret[0].([]params.PromotionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockPromotionServiceMockRecorder) ListPromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*MockPromotionService)(nil).ListPromotions), ctx)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation.go
+//
+// Generated by this command:
+//
+//	mockgen -source reservation.go -destination ../../mock/handler/mock_reservation.go -package mockhandler
+//
+
+// Package mockhandler is a generated GoMock package.
+package mockhandler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationService is a mock of ReservationService interface.
+type MockReservationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationServiceMockRecorder is the mock recorder for MockReservationService.
+type MockReservationServiceMockRecorder struct {
+	mock *MockReservationService
+}
+
+// NewMockReservationService creates a new mock instance.
+func NewMockReservationService(ctrl *gomock.Controller) *MockReservationService {
+	mock := &MockReservationService{ctrl: ctrl}
+	mock.recorder = &MockReservationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationService) EXPECT() *MockReservationServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelReservation mocks base method.
+func (m *MockReservationService) CancelReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReservation", ctx, id)
+	ret0, _ := ret[0].(*params.ReservationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelReservation indicates an expected call of CancelReservation.
+func (mr *MockReservationServiceMockRecorder) CancelReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservation", reflect.TypeOf((*MockReservationService)(nil).CancelReservation), ctx, id)
+}
+
+// ConfirmReservation mocks base method.
+func (m *MockReservationService) ConfirmReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReservation", ctx, id)
+	ret0, _ := ret[0].(*params.ReservationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmReservation indicates an expected call of ConfirmReservation.
+func (mr *MockReservationServiceMockRecorder) ConfirmReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReservation", reflect.TypeOf((*MockReservationService)(nil).ConfirmReservation), ctx, id)
+}
+
+// CreateReservation mocks base method.
+func (m *MockReservationService) CreateReservation(ctx context.Context, req params.CreateReservationRequest) (*params.ReservationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", ctx, req)
+	ret0, _ := ret[0].(*params.ReservationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockReservationServiceMockRecorder) CreateReservation(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockReservationService)(nil).CreateReservation), ctx, req)
+}
+
+// GetReservation mocks base method.
+func (m *MockReservationService) GetReservation(ctx context.Context, id int) (*params.ReservationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(*params.ReservationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockReservationServiceMockRecorder) GetReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockReservationService)(nil).GetReservation), ctx, id)
+}
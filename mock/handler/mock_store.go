@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store.go
+//
+// Generated by this command:
+//
+//	mockgen -source store.go -destination ../../mock/handler/mock_store.go -package mockhandler
+//
+
+// Package mockhandler is a generated GoMock package.
+package mockhandler
+
+import (
+	context "context"
+	reflect "reflect"
+
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStoreService is a mock of StoreService interface.
+type MockStoreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreServiceMockRecorder is the mock recorder for MockStoreService.
+type MockStoreServiceMockRecorder struct {
+	mock *MockStoreService
+}
+
+// NewMockStoreService creates a new mock instance.
+func NewMockStoreService(ctrl *gomock.Controller) *MockStoreService {
+	mock := &MockStoreService{ctrl: ctrl}
+	mock.recorder = &MockStoreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreService) EXPECT() *MockStoreServiceMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockStoreService) AdjustStock(ctx context.Context, storeID int, req params.AdjustStockRequest) (*params.StockLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, storeID, req)
+	ret0, _ := ret[0].(*params.StockLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockStoreServiceMockRecorder) AdjustStock(ctx, storeID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockStoreService)(nil).AdjustStock), ctx, storeID, req)
+}
+
+// CreateStore mocks base method.
+func (m *MockStoreService) CreateStore(ctx context.Context, req params.CreateStoreRequest) (*params.StoreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStore", ctx, req)
+	ret0, _ := ret[0].(*params.StoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStore indicates an expected call of CreateStore.
+func (mr *MockStoreServiceMockRecorder) CreateStore(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStore", reflect.TypeOf((*MockStoreService)(nil).CreateStore), ctx, req)
+}
+
+// GetStore mocks base method.
+func (m *MockStoreService) GetStore(ctx context.Context, id int) (*params.StoreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStore", ctx, id)
+	ret0, _ := ret[0].(*params.StoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStore indicates an expected call of GetStore.
+func (mr *MockStoreServiceMockRecorder) GetStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStore", reflect.TypeOf((*MockStoreService)(nil).GetStore), ctx, id)
+}
+
+// ListStockLevels mocks base method.
+func (m *MockStoreService) ListStockLevels(ctx context.Context, storeID int) ([]params.StockLevelResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockLevels", ctx, storeID)
+	ret0, _ := ret[0].([]params.StockLevelResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockLevels indicates an expected call of ListStockLevels.
+func (mr *MockStoreServiceMockRecorder) ListStockLevels(ctx, storeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockLevels", reflect.TypeOf((*MockStoreService)(nil).ListStockLevels), ctx, storeID)
+}
+
+// ListStores mocks base method.
+func (m *MockStoreService) ListStores(ctx context.Context) ([]params.StoreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStores", ctx)
+	ret0, _ := ret[0].([]params.StoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStores indicates an expected call of ListStores.
+func (mr *MockStoreServiceMockRecorder) ListStores(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStores", reflect.TypeOf((*MockStoreService)(nil).ListStores), ctx)
+}
@@ -12,6 +12,7 @@ package mockservice
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/elangreza/lion-superindo/internal/domain"
 	params "github.com/elangreza/lion-superindo/internal/params"
@@ -42,6 +43,21 @@ func (m *MockDbRepo) EXPECT() *MockDbRepoMockRecorder {
 	return m.recorder
 }
 
+// ApplyDueScheduledPrices mocks base method.
+func (m *MockDbRepo) ApplyDueScheduledPrices(ctx context.Context, now time.Time, limit int) ([]domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDueScheduledPrices", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDueScheduledPrices indicates an expected call of ApplyDueScheduledPrices.
+func (mr *MockDbRepoMockRecorder) ApplyDueScheduledPrices(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDueScheduledPrices", reflect.TypeOf((*MockDbRepo)(nil).ApplyDueScheduledPrices), ctx, now, limit)
+}
+
 // CountProducts mocks base method.
 func (m *MockDbRepo) CountProducts(ctx context.Context, req params.ListProductsQueryParams) (int, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +73,21 @@ func (mr *MockDbRepoMockRecorder) CountProducts(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockDbRepo)(nil).CountProducts), ctx, req)
 }
 
+// CountProductsByType mocks base method.
+func (m *MockDbRepo) CountProductsByType(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByType", ctx, req)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByType indicates an expected call of CountProductsByType.
+func (mr *MockDbRepoMockRecorder) CountProductsByType(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByType", reflect.TypeOf((*MockDbRepo)(nil).CountProductsByType), ctx, req)
+}
+
 // CreateProduct mocks base method.
 func (m *MockDbRepo) CreateProduct(ctx context.Context, req params.CreateProductRequest) (int, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +103,197 @@ func (mr *MockDbRepoMockRecorder) CreateProduct(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockDbRepo)(nil).CreateProduct), ctx, req)
 }
 
+// CreateProducts mocks base method.
+func (m *MockDbRepo) CreateProducts(ctx context.Context, reqs []params.CreateProductRequest) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProducts", ctx, reqs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProducts indicates an expected call of CreateProducts.
+func (mr *MockDbRepoMockRecorder) CreateProducts(ctx, reqs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProducts", reflect.TypeOf((*MockDbRepo)(nil).CreateProducts), ctx, reqs)
+}
+
+// CreateScheduledPrice mocks base method.
+func (m *MockDbRepo) CreateScheduledPrice(ctx context.Context, productID int, req params.ScheduleProductPriceRequest) (*domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateScheduledPrice", ctx, productID, req)
+	ret0, _ := ret[0].(*domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateScheduledPrice indicates an expected call of CreateScheduledPrice.
+func (mr *MockDbRepoMockRecorder) CreateScheduledPrice(ctx, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledPrice", reflect.TypeOf((*MockDbRepo)(nil).CreateScheduledPrice), ctx, productID, req)
+}
+
+// CreateVariant mocks base method.
+func (m *MockDbRepo) CreateVariant(ctx context.Context, productID int, req params.CreateVariantRequest) (*domain.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", ctx, productID, req)
+	ret0, _ := ret[0].(*domain.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockDbRepoMockRecorder) CreateVariant(ctx, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockDbRepo)(nil).CreateVariant), ctx, productID, req)
+}
+
+// DeleteProduct mocks base method.
+func (m *MockDbRepo) DeleteProduct(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProduct", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProduct indicates an expected call of DeleteProduct.
+func (mr *MockDbRepoMockRecorder) DeleteProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProduct", reflect.TypeOf((*MockDbRepo)(nil).DeleteProduct), ctx, id)
+}
+
+// DeleteScheduledPrice mocks base method.
+func (m *MockDbRepo) DeleteScheduledPrice(ctx context.Context, productID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteScheduledPrice", ctx, productID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteScheduledPrice indicates an expected call of DeleteScheduledPrice.
+func (mr *MockDbRepoMockRecorder) DeleteScheduledPrice(ctx, productID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteScheduledPrice", reflect.TypeOf((*MockDbRepo)(nil).DeleteScheduledPrice), ctx, productID, id)
+}
+
+// DeleteVariant mocks base method.
+func (m *MockDbRepo) DeleteVariant(ctx context.Context, productID, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", ctx, productID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockDbRepoMockRecorder) DeleteVariant(ctx, productID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockDbRepo)(nil).DeleteVariant), ctx, productID, id)
+}
+
+// ExistingProductBarcodes mocks base method.
+func (m *MockDbRepo) ExistingProductBarcodes(ctx context.Context, barcodes []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingProductBarcodes", ctx, barcodes)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingProductBarcodes indicates an expected call of ExistingProductBarcodes.
+func (mr *MockDbRepoMockRecorder) ExistingProductBarcodes(ctx, barcodes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingProductBarcodes", reflect.TypeOf((*MockDbRepo)(nil).ExistingProductBarcodes), ctx, barcodes)
+}
+
+// ExistingProductNames mocks base method.
+func (m *MockDbRepo) ExistingProductNames(ctx context.Context, names []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingProductNames", ctx, names)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingProductNames indicates an expected call of ExistingProductNames.
+func (mr *MockDbRepoMockRecorder) ExistingProductNames(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingProductNames", reflect.TypeOf((*MockDbRepo)(nil).ExistingProductNames), ctx, names)
+}
+
+// ExistingProductSKUs mocks base method.
+func (m *MockDbRepo) ExistingProductSKUs(ctx context.Context, skus []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingProductSKUs", ctx, skus)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingProductSKUs indicates an expected call of ExistingProductSKUs.
+func (mr *MockDbRepoMockRecorder) ExistingProductSKUs(ctx, skus any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingProductSKUs", reflect.TypeOf((*MockDbRepo)(nil).ExistingProductSKUs), ctx, skus)
+}
+
+// ExportProducts mocks base method.
+func (m *MockDbRepo) ExportProducts(ctx context.Context, req params.ListProductsQueryParams, fn func(domain.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", ctx, req, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockDbRepoMockRecorder) ExportProducts(ctx, req, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockDbRepo)(nil).ExportProducts), ctx, req, fn)
+}
+
+// GetProduct mocks base method.
+func (m *MockDbRepo) GetProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockDbRepoMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockDbRepo)(nil).GetProduct), ctx, id)
+}
+
+// GetProductByBarcode mocks base method.
+func (m *MockDbRepo) GetProductByBarcode(ctx context.Context, barcode string) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductByBarcode indicates an expected call of GetProductByBarcode.
+func (mr *MockDbRepoMockRecorder) GetProductByBarcode(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByBarcode", reflect.TypeOf((*MockDbRepo)(nil).GetProductByBarcode), ctx, barcode)
+}
+
+// ListProductPrices mocks base method.
+func (m *MockDbRepo) ListProductPrices(ctx context.Context, id int) ([]domain.ProductPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductPrices", ctx, id)
+	ret0, _ := ret[0].([]domain.ProductPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductPrices indicates an expected call of ListProductPrices.
+func (mr *MockDbRepoMockRecorder) ListProductPrices(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductPrices", reflect.TypeOf((*MockDbRepo)(nil).ListProductPrices), ctx, id)
+}
+
 // ListProducts mocks base method.
 func (m *MockDbRepo) ListProducts(ctx context.Context, req params.ListProductsQueryParams) ([]domain.Product, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +309,232 @@ func (mr *MockDbRepoMockRecorder) ListProducts(ctx, req any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockDbRepo)(nil).ListProducts), ctx, req)
 }
 
+// ListPromotionsEndingAfter mocks base method.
+func (m *MockDbRepo) ListPromotionsEndingAfter(ctx context.Context, t time.Time) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotionsEndingAfter", ctx, t)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotionsEndingAfter indicates an expected call of ListPromotionsEndingAfter.
+func (mr *MockDbRepoMockRecorder) ListPromotionsEndingAfter(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotionsEndingAfter", reflect.TypeOf((*MockDbRepo)(nil).ListPromotionsEndingAfter), ctx, t)
+}
+
+// ListScheduledPrices mocks base method.
+func (m *MockDbRepo) ListScheduledPrices(ctx context.Context, productID int) ([]domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledPrices", ctx, productID)
+	ret0, _ := ret[0].([]domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledPrices indicates an expected call of ListScheduledPrices.
+func (mr *MockDbRepoMockRecorder) ListScheduledPrices(ctx, productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledPrices", reflect.TypeOf((*MockDbRepo)(nil).ListScheduledPrices), ctx, productID)
+}
+
+// ProductBarcodeExists mocks base method.
+func (m *MockDbRepo) ProductBarcodeExists(ctx context.Context, barcode string, exceptID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductBarcodeExists", ctx, barcode, exceptID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductBarcodeExists indicates an expected call of ProductBarcodeExists.
+func (mr *MockDbRepoMockRecorder) ProductBarcodeExists(ctx, barcode, exceptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductBarcodeExists", reflect.TypeOf((*MockDbRepo)(nil).ProductBarcodeExists), ctx, barcode, exceptID)
+}
+
+// ProductNameExists mocks base method.
+func (m *MockDbRepo) ProductNameExists(ctx context.Context, name string, exceptID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductNameExists", ctx, name, exceptID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductNameExists indicates an expected call of ProductNameExists.
+func (mr *MockDbRepoMockRecorder) ProductNameExists(ctx, name, exceptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductNameExists", reflect.TypeOf((*MockDbRepo)(nil).ProductNameExists), ctx, name, exceptID)
+}
+
+// ProductSKUExists mocks base method.
+func (m *MockDbRepo) ProductSKUExists(ctx context.Context, sku string, exceptID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductSKUExists", ctx, sku, exceptID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductSKUExists indicates an expected call of ProductSKUExists.
+func (mr *MockDbRepoMockRecorder) ProductSKUExists(ctx, sku, exceptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductSKUExists", reflect.TypeOf((*MockDbRepo)(nil).ProductSKUExists), ctx, sku, exceptID)
+}
+
+// ProductTypeExists mocks base method.
+func (m *MockDbRepo) ProductTypeExists(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductTypeExists", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductTypeExists indicates an expected call of ProductTypeExists.
+func (mr *MockDbRepoMockRecorder) ProductTypeExists(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductTypeExists", reflect.TypeOf((*MockDbRepo)(nil).ProductTypeExists), ctx, name)
+}
+
+// ProductTypeNames mocks base method.
+func (m *MockDbRepo) ProductTypeNames(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductTypeNames", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProductTypeNames indicates an expected call of ProductTypeNames.
+func (mr *MockDbRepoMockRecorder) ProductTypeNames(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductTypeNames", reflect.TypeOf((*MockDbRepo)(nil).ProductTypeNames), ctx)
+}
+
+// RestoreProduct mocks base method.
+func (m *MockDbRepo) RestoreProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockDbRepoMockRecorder) RestoreProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockDbRepo)(nil).RestoreProduct), ctx, id)
+}
+
+// SuggestProductNames mocks base method.
+func (m *MockDbRepo) SuggestProductNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestProductNames", ctx, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestProductNames indicates an expected call of SuggestProductNames.
+func (mr *MockDbRepoMockRecorder) SuggestProductNames(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestProductNames", reflect.TypeOf((*MockDbRepo)(nil).SuggestProductNames), ctx, prefix, limit)
+}
+
+// SuggestProductTypeNames mocks base method.
+func (m *MockDbRepo) SuggestProductTypeNames(ctx context.Context, prefix string, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestProductTypeNames", ctx, prefix, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestProductTypeNames indicates an expected call of SuggestProductTypeNames.
+func (mr *MockDbRepoMockRecorder) SuggestProductTypeNames(ctx, prefix, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestProductTypeNames", reflect.TypeOf((*MockDbRepo)(nil).SuggestProductTypeNames), ctx, prefix, limit)
+}
+
+// UpdateProduct mocks base method.
+func (m *MockDbRepo) UpdateProduct(ctx context.Context, id int, req params.UpdateProductRequest) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProduct", ctx, id, req)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProduct indicates an expected call of UpdateProduct.
+func (mr *MockDbRepoMockRecorder) UpdateProduct(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockDbRepo)(nil).UpdateProduct), ctx, id, req)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockDbRepo) UpdateVariant(ctx context.Context, productID, id int, req params.UpdateVariantRequest) (*domain.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", ctx, productID, id, req)
+	ret0, _ := ret[0].(*domain.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockDbRepoMockRecorder) UpdateVariant(ctx, productID, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockDbRepo)(nil).UpdateVariant), ctx, productID, id, req)
+}
+
+// UpsertProducts mocks base method.
+func (m *MockDbRepo) UpsertProducts(ctx context.Context, reqs []params.CreateProductRequest) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertProducts", ctx, reqs)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpsertProducts indicates an expected call of UpsertProducts.
+func (mr *MockDbRepoMockRecorder) UpsertProducts(ctx, reqs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertProducts", reflect.TypeOf((*MockDbRepo)(nil).UpsertProducts), ctx, reqs)
+}
+
+// VariantAttributesExist mocks base method.
+func (m *MockDbRepo) VariantAttributesExist(ctx context.Context, productID int, attributes map[string]string, exceptID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantAttributesExist", ctx, productID, attributes, exceptID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantAttributesExist indicates an expected call of VariantAttributesExist.
+func (mr *MockDbRepoMockRecorder) VariantAttributesExist(ctx, productID, attributes, exceptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantAttributesExist", reflect.TypeOf((*MockDbRepo)(nil).VariantAttributesExist), ctx, productID, attributes, exceptID)
+}
+
+// VariantSKUExists mocks base method.
+func (m *MockDbRepo) VariantSKUExists(ctx context.Context, sku string, exceptID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VariantSKUExists", ctx, sku, exceptID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VariantSKUExists indicates an expected call of VariantSKUExists.
+func (mr *MockDbRepoMockRecorder) VariantSKUExists(ctx, sku, exceptID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VariantSKUExists", reflect.TypeOf((*MockDbRepo)(nil).VariantSKUExists), ctx, sku, exceptID)
+}
+
 // MockCacheRepo is a mock of CacheRepo interface.
 type MockCacheRepo struct {
 	ctrl     *gomock.Controller
@@ -111,18 +559,116 @@ func (m *MockCacheRepo) EXPECT() *MockCacheRepoMockRecorder {
 	return m.recorder
 }
 
+// CacheBarcode mocks base method.
+func (m *MockCacheRepo) CacheBarcode(ctx context.Context, barcode string, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheBarcode", ctx, barcode, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheBarcode indicates an expected call of CacheBarcode.
+func (mr *MockCacheRepoMockRecorder) CacheBarcode(ctx, barcode, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheBarcode", reflect.TypeOf((*MockCacheRepo)(nil).CacheBarcode), ctx, barcode, id)
+}
+
+// CacheProduct mocks base method.
+func (m *MockCacheRepo) CacheProduct(ctx context.Context, product domain.Product) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheProduct", ctx, product)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheProduct indicates an expected call of CacheProduct.
+func (mr *MockCacheRepoMockRecorder) CacheProduct(ctx, product any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheProduct", reflect.TypeOf((*MockCacheRepo)(nil).CacheProduct), ctx, product)
+}
+
+// CacheProductTypeFacets mocks base method.
+func (m *MockCacheRepo) CacheProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams, counts map[string]int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheProductTypeFacets", ctx, req, counts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheProductTypeFacets indicates an expected call of CacheProductTypeFacets.
+func (mr *MockCacheRepoMockRecorder) CacheProductTypeFacets(ctx, req, counts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheProductTypeFacets", reflect.TypeOf((*MockCacheRepo)(nil).CacheProductTypeFacets), ctx, req, counts)
+}
+
 // CacheProducts mocks base method.
-func (m *MockCacheRepo) CacheProducts(ctx context.Context, req params.ListProductsQueryParams, CountProducts int, ListProducts []domain.Product) error {
+func (m *MockCacheRepo) CacheProducts(ctx context.Context, req params.ListProductsQueryParams, countProducts int, listProducts []domain.Product) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CacheProducts", ctx, req, CountProducts, ListProducts)
+	ret := m.ctrl.Call(m, "CacheProducts", ctx, req, countProducts, listProducts)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CacheProducts indicates an expected call of CacheProducts.
-func (mr *MockCacheRepoMockRecorder) CacheProducts(ctx, req, CountProducts, ListProducts any) *gomock.Call {
+func (mr *MockCacheRepoMockRecorder) CacheProducts(ctx, req, countProducts, listProducts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheProducts", reflect.TypeOf((*MockCacheRepo)(nil).CacheProducts), ctx, req, countProducts, listProducts)
+}
+
+// CachePromotions mocks base method.
+func (m *MockCacheRepo) CachePromotions(ctx context.Context, promotions []domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachePromotions", ctx, promotions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CachePromotions indicates an expected call of CachePromotions.
+func (mr *MockCacheRepoMockRecorder) CachePromotions(ctx, promotions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachePromotions", reflect.TypeOf((*MockCacheRepo)(nil).CachePromotions), ctx, promotions)
+}
+
+// CacheSuggestions mocks base method.
+func (m *MockCacheRepo) CacheSuggestions(ctx context.Context, req params.SuggestProductsQueryParams, res params.SuggestProductsResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheSuggestions", ctx, req, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CacheSuggestions indicates an expected call of CacheSuggestions.
+func (mr *MockCacheRepoMockRecorder) CacheSuggestions(ctx, req, res any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheProducts", reflect.TypeOf((*MockCacheRepo)(nil).CacheProducts), ctx, req, CountProducts, ListProducts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheSuggestions", reflect.TypeOf((*MockCacheRepo)(nil).CacheSuggestions), ctx, req, res)
+}
+
+// DeleteCachedBarcode mocks base method.
+func (m *MockCacheRepo) DeleteCachedBarcode(ctx context.Context, barcode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCachedBarcode", ctx, barcode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCachedBarcode indicates an expected call of DeleteCachedBarcode.
+func (mr *MockCacheRepoMockRecorder) DeleteCachedBarcode(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCachedBarcode", reflect.TypeOf((*MockCacheRepo)(nil).DeleteCachedBarcode), ctx, barcode)
+}
+
+// DeleteCachedProduct mocks base method.
+func (m *MockCacheRepo) DeleteCachedProduct(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCachedProduct", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCachedProduct indicates an expected call of DeleteCachedProduct.
+func (mr *MockCacheRepoMockRecorder) DeleteCachedProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCachedProduct", reflect.TypeOf((*MockCacheRepo)(nil).DeleteCachedProduct), ctx, id)
 }
 
 // FlushAllProducts mocks base method.
@@ -139,6 +685,78 @@ func (mr *MockCacheRepoMockRecorder) FlushAllProducts(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushAllProducts", reflect.TypeOf((*MockCacheRepo)(nil).FlushAllProducts), ctx)
 }
 
+// FlushProductDetails mocks base method.
+func (m *MockCacheRepo) FlushProductDetails(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushProductDetails", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushProductDetails indicates an expected call of FlushProductDetails.
+func (mr *MockCacheRepoMockRecorder) FlushProductDetails(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushProductDetails", reflect.TypeOf((*MockCacheRepo)(nil).FlushProductDetails), ctx)
+}
+
+// FlushPromotions mocks base method.
+func (m *MockCacheRepo) FlushPromotions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushPromotions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushPromotions indicates an expected call of FlushPromotions.
+func (mr *MockCacheRepoMockRecorder) FlushPromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushPromotions", reflect.TypeOf((*MockCacheRepo)(nil).FlushPromotions), ctx)
+}
+
+// FlushSuggestions mocks base method.
+func (m *MockCacheRepo) FlushSuggestions(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushSuggestions", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushSuggestions indicates an expected call of FlushSuggestions.
+func (mr *MockCacheRepoMockRecorder) FlushSuggestions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushSuggestions", reflect.TypeOf((*MockCacheRepo)(nil).FlushSuggestions), ctx)
+}
+
+// GetCachedBarcode mocks base method.
+func (m *MockCacheRepo) GetCachedBarcode(ctx context.Context, barcode string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedBarcode", ctx, barcode)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedBarcode indicates an expected call of GetCachedBarcode.
+func (mr *MockCacheRepoMockRecorder) GetCachedBarcode(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedBarcode", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedBarcode), ctx, barcode)
+}
+
+// GetCachedProduct mocks base method.
+func (m *MockCacheRepo) GetCachedProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedProduct indicates an expected call of GetCachedProduct.
+func (mr *MockCacheRepoMockRecorder) GetCachedProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedProduct", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedProduct), ctx, id)
+}
+
 // GetCachedProductCount mocks base method.
 func (m *MockCacheRepo) GetCachedProductCount(ctx context.Context, req params.ListProductsQueryParams) (int, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +772,21 @@ func (mr *MockCacheRepoMockRecorder) GetCachedProductCount(ctx, req any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedProductCount", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedProductCount), ctx, req)
 }
 
+// GetCachedProductTypeFacets mocks base method.
+func (m *MockCacheRepo) GetCachedProductTypeFacets(ctx context.Context, req params.ListProductsQueryParams) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedProductTypeFacets", ctx, req)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedProductTypeFacets indicates an expected call of GetCachedProductTypeFacets.
+func (mr *MockCacheRepoMockRecorder) GetCachedProductTypeFacets(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedProductTypeFacets", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedProductTypeFacets), ctx, req)
+}
+
 // GetCachedProducts mocks base method.
 func (m *MockCacheRepo) GetCachedProducts(ctx context.Context, req params.ListProductsQueryParams) ([]domain.Product, error) {
 	m.ctrl.T.Helper()
@@ -168,3 +801,33 @@ func (mr *MockCacheRepoMockRecorder) GetCachedProducts(ctx, req any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedProducts", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedProducts), ctx, req)
 }
+
+// GetCachedPromotions mocks base method.
+func (m *MockCacheRepo) GetCachedPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedPromotions", ctx)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedPromotions indicates an expected call of GetCachedPromotions.
+func (mr *MockCacheRepoMockRecorder) GetCachedPromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedPromotions", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedPromotions), ctx)
+}
+
+// GetCachedSuggestions mocks base method.
+func (m *MockCacheRepo) GetCachedSuggestions(ctx context.Context, req params.SuggestProductsQueryParams) (*params.SuggestProductsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedSuggestions", ctx, req)
+	ret0, _ := ret[0].(*params.SuggestProductsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedSuggestions indicates an expected call of GetCachedSuggestions.
+func (mr *MockCacheRepoMockRecorder) GetCachedSuggestions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedSuggestions", reflect.TypeOf((*MockCacheRepo)(nil).GetCachedSuggestions), ctx, req)
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: product_type.go
+//
+// Generated by this command:
+//
+//	mockgen -source product_type.go -destination ../../mock/service/mock_product_type.go -package mockservice
+//
+
+// Package mockservice is a generated GoMock package.
+package mockservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/elangreza/lion-superindo/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductTypeRepo is a mock of ProductTypeRepo interface.
+type MockProductTypeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductTypeRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockProductTypeRepoMockRecorder is the mock recorder for MockProductTypeRepo.
+type MockProductTypeRepoMockRecorder struct {
+	mock *MockProductTypeRepo
+}
+
+// NewMockProductTypeRepo creates a new mock instance.
+func NewMockProductTypeRepo(ctrl *gomock.Controller) *MockProductTypeRepo {
+	mock := &MockProductTypeRepo{ctrl: ctrl}
+	mock.recorder = &MockProductTypeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductTypeRepo) EXPECT() *MockProductTypeRepoMockRecorder {
+	return m.recorder
+}
+
+// CountChildProductTypes mocks base method.
+func (m *MockProductTypeRepo) CountChildProductTypes(ctx context.Context, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildProductTypes", ctx, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildProductTypes indicates an expected call of CountChildProductTypes.
+func (mr *MockProductTypeRepoMockRecorder) CountChildProductTypes(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildProductTypes", reflect.TypeOf((*MockProductTypeRepo)(nil).CountChildProductTypes), ctx, name)
+}
+
+// CountProductsOfType mocks base method.
+func (m *MockProductTypeRepo) CountProductsOfType(ctx context.Context, name string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsOfType", ctx, name)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsOfType indicates an expected call of CountProductsOfType.
+func (mr *MockProductTypeRepoMockRecorder) CountProductsOfType(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsOfType", reflect.TypeOf((*MockProductTypeRepo)(nil).CountProductsOfType), ctx, name)
+}
+
+// CreateProductType mocks base method.
+func (m *MockProductTypeRepo) CreateProductType(ctx context.Context, name, parent string) (*domain.ProductType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductType", ctx, name, parent)
+	ret0, _ := ret[0].(*domain.ProductType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProductType indicates an expected call of CreateProductType.
+func (mr *MockProductTypeRepoMockRecorder) CreateProductType(ctx, name, parent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductType", reflect.TypeOf((*MockProductTypeRepo)(nil).CreateProductType), ctx, name, parent)
+}
+
+// DeleteProductType mocks base method.
+func (m *MockProductTypeRepo) DeleteProductType(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProductType", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProductType indicates an expected call of DeleteProductType.
+func (mr *MockProductTypeRepoMockRecorder) DeleteProductType(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProductType", reflect.TypeOf((*MockProductTypeRepo)(nil).DeleteProductType), ctx, name)
+}
+
+// GetProductType mocks base method.
+func (m *MockProductTypeRepo) GetProductType(ctx context.Context, name string) (*domain.ProductType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductType", ctx, name)
+	ret0, _ := ret[0].(*domain.ProductType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductType indicates an expected call of GetProductType.
+func (mr *MockProductTypeRepoMockRecorder) GetProductType(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductType", reflect.TypeOf((*MockProductTypeRepo)(nil).GetProductType), ctx, name)
+}
+
+// ListProductTypes mocks base method.
+func (m *MockProductTypeRepo) ListProductTypes(ctx context.Context) ([]domain.ProductType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProductTypes", ctx)
+	ret0, _ := ret[0].([]domain.ProductType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProductTypes indicates an expected call of ListProductTypes.
+func (mr *MockProductTypeRepoMockRecorder) ListProductTypes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductTypes", reflect.TypeOf((*MockProductTypeRepo)(nil).ListProductTypes), ctx)
+}
+
+// MoveProductType mocks base method.
+func (m *MockProductTypeRepo) MoveProductType(ctx context.Context, name, parent string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveProductType", ctx, name, parent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveProductType indicates an expected call of MoveProductType.
+func (mr *MockProductTypeRepoMockRecorder) MoveProductType(ctx, name, parent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveProductType", reflect.TypeOf((*MockProductTypeRepo)(nil).MoveProductType), ctx, name, parent)
+}
+
+// RenameProductType mocks base method.
+func (m *MockProductTypeRepo) RenameProductType(ctx context.Context, name, newName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameProductType", ctx, name, newName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameProductType indicates an expected call of RenameProductType.
+func (mr *MockProductTypeRepoMockRecorder) RenameProductType(ctx, name, newName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameProductType", reflect.TypeOf((*MockProductTypeRepo)(nil).RenameProductType), ctx, name, newName)
+}
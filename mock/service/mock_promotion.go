@@ -0,0 +1,132 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: promotion.go
+//
+// Generated by this command:
+//
+//	mockgen -source promotion.go -destination ../../mock/service/mock_promotion.go -package mockservice
+//
+
+// Package mockservice is a generated GoMock package.
+package mockservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/elangreza/lion-superindo/internal/domain"
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPromotionRepo is a mock of PromotionRepo interface.
+type MockPromotionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionRepoMockRecorder is the mock recorder for MockPromotionRepo.
+type MockPromotionRepoMockRecorder struct {
+	mock *MockPromotionRepo
+}
+
+// NewMockPromotionRepo creates a new mock instance.
+func NewMockPromotionRepo(ctrl *gomock.Controller) *MockPromotionRepo {
+	mock := &MockPromotionRepo{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepo) EXPECT() *MockPromotionRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePromotion mocks base method.
+func (m *MockPromotionRepo) CreatePromotion(ctx context.Context, req params.CreatePromotionRequest) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePromotion", ctx, req)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePromotion indicates an expected call of CreatePromotion.
+func (mr *MockPromotionRepoMockRecorder) CreatePromotion(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePromotion", reflect.TypeOf((*MockPromotionRepo)(nil).CreatePromotion), ctx, req)
+}
+
+// DeletePromotion mocks base method.
+func (m *MockPromotionRepo) DeletePromotion(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePromotion", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePromotion indicates an expected call of DeletePromotion.
+func (mr *MockPromotionRepoMockRecorder) DeletePromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePromotion", reflect.TypeOf((*MockPromotionRepo)(nil).DeletePromotion), ctx, id)
+}
+
+// GetProduct mocks base method.
+func (m *MockPromotionRepo) GetProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockPromotionRepoMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockPromotionRepo)(nil).GetProduct), ctx, id)
+}
+
+// GetProductType mocks base method.
+func (m *MockPromotionRepo) GetProductType(ctx context.Context, name string) (*domain.ProductType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductType", ctx, name)
+	ret0, _ := ret[0].(*domain.ProductType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductType indicates an expected call of GetProductType.
+func (mr *MockPromotionRepoMockRecorder) GetProductType(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductType", reflect.TypeOf((*MockPromotionRepo)(nil).GetProductType), ctx, name)
+}
+
+// GetPromotion mocks base method.
+func (m *MockPromotionRepo) GetPromotion(ctx context.Context, id int) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotion", ctx, id)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromotion indicates an expected call of GetPromotion.
+func (mr *MockPromotionRepoMockRecorder) GetPromotion(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotion", reflect.TypeOf((*MockPromotionRepo)(nil).GetPromotion), ctx, id)
+}
+
+// ListPromotions mocks base method.
+func (m *MockPromotionRepo) ListPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPromotions", ctx)
+	ret0, _ := ret[0].([]domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPromotions indicates an expected call of ListPromotions.
+func (mr *MockPromotionRepoMockRecorder) ListPromotions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPromotions", reflect.TypeOf((*MockPromotionRepo)(nil).ListPromotions), ctx)
+}
@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reservation.go
+//
+// Generated by this command:
+//
+//	mockgen -source reservation.go -destination ../../mock/service/mock_reservation.go -package mockservice
+//
+
+// Package mockservice is a generated GoMock package.
+package mockservice
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/elangreza/lion-superindo/internal/domain"
+	params "github.com/elangreza/lion-superindo/internal/params"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReservationRepo is a mock of ReservationRepo interface.
+type MockReservationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReservationRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockReservationRepoMockRecorder is the mock recorder for MockReservationRepo.
+type MockReservationRepoMockRecorder struct {
+	mock *MockReservationRepo
+}
+
+// NewMockReservationRepo creates a new mock instance.
+func NewMockReservationRepo(ctrl *gomock.Controller) *MockReservationRepo {
+	mock := &MockReservationRepo{ctrl: ctrl}
+	mock.recorder = &MockReservationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReservationRepo) EXPECT() *MockReservationRepoMockRecorder {
+	return m.recorder
+}
+
+// CancelReservation mocks base method.
+func (m *MockReservationRepo) CancelReservation(ctx context.Context, id int) (*domain.Reservation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CancelReservation indicates an expected call of CancelReservation.
+func (mr *MockReservationRepoMockRecorder) CancelReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReservation", reflect.TypeOf((*MockReservationRepo)(nil).CancelReservation), ctx, id)
+}
+
+// ConfirmReservation mocks base method.
+func (m *MockReservationRepo) ConfirmReservation(ctx context.Context, id int) (*domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmReservation indicates an expected call of ConfirmReservation.
+func (mr *MockReservationRepoMockRecorder) ConfirmReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReservation", reflect.TypeOf((*MockReservationRepo)(nil).ConfirmReservation), ctx, id)
+}
+
+// CreateReservation mocks base method.
+func (m *MockReservationRepo) CreateReservation(ctx context.Context, req params.CreateReservationRequest, expiresAt time.Time) (*domain.Reservation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", ctx, req, expiresAt)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockReservationRepoMockRecorder) CreateReservation(ctx, req, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockReservationRepo)(nil).CreateReservation), ctx, req, expiresAt)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockReservationRepo) ExpireReservations(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockReservationRepoMockRecorder) ExpireReservations(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockReservationRepo)(nil).ExpireReservations), ctx, now, limit)
+}
+
+// GetProduct mocks base method.
+func (m *MockReservationRepo) GetProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockReservationRepoMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockReservationRepo)(nil).GetProduct), ctx, id)
+}
+
+// GetReservation mocks base method.
+func (m *MockReservationRepo) GetReservation(ctx context.Context, id int) (*domain.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", ctx, id)
+	ret0, _ := ret[0].(*domain.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockReservationRepoMockRecorder) GetReservation(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockReservationRepo)(nil).GetReservation), ctx, id)
+}
+
+// GetStore mocks base method.
+func (m *MockReservationRepo) GetStore(ctx context.Context, id int) (*domain.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStore", ctx, id)
+	ret0, _ := ret[0].(*domain.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStore indicates an expected call of GetStore.
+func (mr *MockReservationRepoMockRecorder) GetStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStore", reflect.TypeOf((*MockReservationRepo)(nil).GetStore), ctx, id)
+}
@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store.go
+//
+// Generated by this command:
+//
+//	mockgen -source store.go -destination ../../mock/service/mock_store.go -package mockservice
+//
+
+// Package mockservice is a generated GoMock package.
+package mockservice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/elangreza/lion-superindo/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStoreRepo is a mock of StoreRepo interface.
+type MockStoreRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreRepoMockRecorder is the mock recorder for MockStoreRepo.
+type MockStoreRepoMockRecorder struct {
+	mock *MockStoreRepo
+}
+
+// NewMockStoreRepo creates a new mock instance.
+func NewMockStoreRepo(ctrl *gomock.Controller) *MockStoreRepo {
+	mock := &MockStoreRepo{ctrl: ctrl}
+	mock.recorder = &MockStoreRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreRepo) EXPECT() *MockStoreRepoMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockStoreRepo) AdjustStock(ctx context.Context, storeID, productID, delta int) (*domain.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, storeID, productID, delta)
+	ret0, _ := ret[0].(*domain.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockStoreRepoMockRecorder) AdjustStock(ctx, storeID, productID, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockStoreRepo)(nil).AdjustStock), ctx, storeID, productID, delta)
+}
+
+// CreateStore mocks base method.
+func (m *MockStoreRepo) CreateStore(ctx context.Context, name string) (*domain.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStore", ctx, name)
+	ret0, _ := ret[0].(*domain.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStore indicates an expected call of CreateStore.
+func (mr *MockStoreRepoMockRecorder) CreateStore(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStore", reflect.TypeOf((*MockStoreRepo)(nil).CreateStore), ctx, name)
+}
+
+// GetProduct mocks base method.
+func (m *MockStoreRepo) GetProduct(ctx context.Context, id int) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreRepoMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStoreRepo)(nil).GetProduct), ctx, id)
+}
+
+// GetStore mocks base method.
+func (m *MockStoreRepo) GetStore(ctx context.Context, id int) (*domain.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStore", ctx, id)
+	ret0, _ := ret[0].(*domain.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStore indicates an expected call of GetStore.
+func (mr *MockStoreRepoMockRecorder) GetStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStore", reflect.TypeOf((*MockStoreRepo)(nil).GetStore), ctx, id)
+}
+
+// ListStockLevels mocks base method.
+func (m *MockStoreRepo) ListStockLevels(ctx context.Context, storeID int) ([]domain.StockLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockLevels", ctx, storeID)
+	ret0, _ := ret[0].([]domain.StockLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockLevels indicates an expected call of ListStockLevels.
+func (mr *MockStoreRepoMockRecorder) ListStockLevels(ctx, storeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockLevels", reflect.TypeOf((*MockStoreRepo)(nil).ListStockLevels), ctx, storeID)
+}
+
+// ListStores mocks base method.
+func (m *MockStoreRepo) ListStores(ctx context.Context) ([]domain.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStores", ctx)
+	ret0, _ := ret[0].([]domain.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStores indicates an expected call of ListStores.
+func (mr *MockStoreRepoMockRecorder) ListStores(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStores", reflect.TypeOf((*MockStoreRepo)(nil).ListStores), ctx)
+}
+
+// StoreNameExists mocks base method.
+func (m *MockStoreRepo) StoreNameExists(ctx context.Context, name string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreNameExists", ctx, name)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StoreNameExists indicates an expected call of StoreNameExists.
+func (mr *MockStoreRepoMockRecorder) StoreNameExists(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNameExists", reflect.TypeOf((*MockStoreRepo)(nil).StoreNameExists), ctx, name)
+}
@@ -0,0 +1,22 @@
+package errs
+
+import (
+	"net/http"
+)
+
+// ConflictError is returned when the request clashes with the current state of a resource.
+type ConflictError struct {
+	Message string
+}
+
+func (c ConflictError) Error() string {
+	if c.Message == "" {
+		return "conflict"
+	}
+
+	return c.Message
+}
+
+func (c ConflictError) HttpStatusCode() int {
+	return http.StatusConflict
+}
@@ -0,0 +1,21 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NotAcceptableError is returned when none of the media types in the Accept header can be sent.
+type NotAcceptableError struct {
+	Accept    string
+	Supported []string
+}
+
+func (v NotAcceptableError) Error() string {
+	return fmt.Sprintf("media type %s not acceptable, supported media types are %s", v.Accept, strings.Join(v.Supported, ", "))
+}
+
+func (a NotAcceptableError) HttpStatusCode() int {
+	return http.StatusNotAcceptable
+}
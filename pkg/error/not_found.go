@@ -0,0 +1,22 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type NotFoundError struct {
+	Message string
+}
+
+func (n NotFoundError) Error() string {
+	if n.Message == "" {
+		return "not found"
+	}
+
+	return fmt.Sprintf("%s not found", n.Message)
+}
+
+func (a NotFoundError) HttpStatusCode() int {
+	return http.StatusNotFound
+}